@@ -0,0 +1,195 @@
+package openskill
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// exactBalanceLimit is the largest amount of players for which BalanceTeams goes through
+// every possible split. Larger pools are balanced with a local search.
+const exactBalanceLimit = 12
+
+// balanceMaxIterations bounds the amount of swaps done by the local search of BalanceTeams.
+const balanceMaxIterations = 1000
+
+// BalanceObjective represents what BalanceTeams optimizes for. Its zero value is MaximizeDraw.
+type BalanceObjective int
+
+const (
+	// MaximizeDraw looks for the split with the highest probability of a draw, as given by PredictDraw.
+	MaximizeDraw BalanceObjective = iota
+
+	// MinimizeWinSpread looks for the split with the smallest difference between the highest
+	// and the lowest win probability of the teams, as given by PredictWin.
+	MinimizeWinSpread
+
+	// MaximizeQuality looks for the split with the highest MatchQuality.
+	MaximizeQuality
+)
+
+// balanceScore returns a function that tells how balanced a split is, where higher is better.
+func balanceScore(objective BalanceObjective, options *Options) func(teams []Team) float64 {
+	switch objective {
+	case MinimizeWinSpread:
+		return func(teams []Team) float64 {
+			probabilities := PredictWin(teams, options)
+			return lo.Min(probabilities) - lo.Max(probabilities)
+		}
+	case MaximizeQuality:
+		return func(teams []Team) float64 {
+			return MatchQuality(teams, options)
+		}
+	default:
+		return func(teams []Team) float64 {
+			return PredictDraw(teams, options)
+		}
+	}
+}
+
+// BalanceTeams splits a pool of players into teams with the given sizes, such as []int{5, 5} for a
+// 5v5 match, looking for the fairest split according to the objective. Up to 12 players,
+// every possible split is evaluated, while larger pools start from a snake draft by Ordinal and are
+// improved by swapping players between teams until no swap makes the match fairer. The result has
+// one team per size, in the same order, holding the same pointers that were given. It returns an
+// error wrapping ErrBalanceSizes when the sizes do not add up to the amount of players, or
+// ErrNilRating when one of the players is nil.
+func BalanceTeams(players []*Rating, sizes []int, objective BalanceObjective, options *Options) ([]Team, error) {
+	if len(sizes) == 0 || lo.Sum(sizes) != len(players) || lo.Min(sizes) < 1 {
+		return nil, fmt.Errorf("%w: sizes %v for %d players", ErrBalanceSizes, sizes, len(players))
+	}
+
+	for i, player := range players {
+		if player == nil {
+			return nil, fmt.Errorf("%w: player %d", ErrNilRating, i)
+		}
+	}
+
+	// the weights and the outcome of options belong to a played game, not to the teams formed here.
+	options = subgameOptions(options, nil)
+	score := balanceScore(objective, options)
+
+	if len(players) <= exactBalanceLimit {
+		return exactBalance(players, sizes, score), nil
+	}
+
+	return localSearchBalance(players, sizes, score, options), nil
+}
+
+// exactBalance goes through every split of the players, skipping the ones that only differ by
+// swapping whole teams of the same size.
+func exactBalance(players []*Rating, sizes []int, score func(teams []Team) float64) []Team {
+	current := lo.Map(sizes, func(size int, index int) Team {
+		return make(Team, 0, size)
+	})
+
+	var best []Team
+	bestScore := 0.0
+
+	var assign func(player int)
+	assign = func(player int) {
+		if player == len(players) {
+			if s := score(current); best == nil || s > bestScore {
+				bestScore = s
+				best = lo.Map(current, func(item Team, index int) Team {
+					return append(Team{}, item...)
+				})
+			}
+			return
+		}
+
+		for t := range current {
+			if len(current[t]) == sizes[t] {
+				continue
+			}
+
+			// an empty team is interchangeable with any other empty team of the same size.
+			if len(current[t]) == 0 && lo.ContainsBy(lo.Range(t), func(other int) bool {
+				return sizes[other] == sizes[t] && len(current[other]) == 0
+			}) {
+				continue
+			}
+
+			current[t] = append(current[t], players[player])
+			assign(player + 1)
+			current[t] = current[t][:len(current[t])-1]
+		}
+	}
+
+	assign(0)
+
+	return best
+}
+
+// localSearchBalance builds the teams with a snake draft by Ordinal, then keeps doing the swap of
+// players that improves the score the most, until no swap improves it.
+func localSearchBalance(players []*Rating, sizes []int, score func(teams []Team) float64, options *Options) []Team {
+	sorted := append([]*Rating{}, players...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return Ordinal(*sorted[i], options) > Ordinal(*sorted[j], options)
+	})
+
+	teams := lo.Map(sizes, func(size int, index int) Team {
+		return make(Team, 0, size)
+	})
+
+	forward := true
+	t := 0
+	for _, player := range sorted {
+		for len(teams[t]) == sizes[t] {
+			t, forward = snakeNext(t, forward, len(teams))
+		}
+		teams[t] = append(teams[t], player)
+		t, forward = snakeNext(t, forward, len(teams))
+	}
+
+	currentScore := score(teams)
+
+	for iteration := 0; iteration < balanceMaxIterations; iteration++ {
+		bestScore := currentScore
+		var bestSwap []int
+
+		for a := 0; a < len(teams); a++ {
+			for b := a + 1; b < len(teams); b++ {
+				for i := range teams[a] {
+					for j := range teams[b] {
+						teams[a][i], teams[b][j] = teams[b][j], teams[a][i]
+						if s := score(teams); s > bestScore {
+							bestScore = s
+							bestSwap = []int{a, i, b, j}
+						}
+						teams[a][i], teams[b][j] = teams[b][j], teams[a][i]
+					}
+				}
+			}
+		}
+
+		if bestSwap == nil {
+			break
+		}
+
+		a, i, b, j := bestSwap[0], bestSwap[1], bestSwap[2], bestSwap[3]
+		teams[a][i], teams[b][j] = teams[b][j], teams[a][i]
+		currentScore = bestScore
+	}
+
+	return teams
+}
+
+// snakeNext returns the next team to pick on a snake draft, which goes back and forth over the teams.
+func snakeNext(t int, forward bool, amount int) (int, bool) {
+	if amount == 1 {
+		return 0, forward
+	}
+	if forward {
+		if t == amount-1 {
+			return t, false
+		}
+		return t + 1, true
+	}
+	if t == 0 {
+		return t, true
+	}
+	return t - 1, false
+}
@@ -0,0 +1,85 @@
+package openskill_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+func TestBalanceTeams(t *testing.T) {
+	newPlayers := func(skills ...float64) []*openskill.Rating {
+		players := []*openskill.Rating{}
+		for _, skill := range skills {
+			players = append(players, openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: skill, SkillUncertaintyDegree: 2}, nil))
+		}
+		return players
+	}
+
+	for _, objective := range []openskill.BalanceObjective{openskill.MaximizeDraw, openskill.MinimizeWinSpread, openskill.MaximizeQuality} {
+		players := newPlayers(30, 28, 22, 20)
+
+		teams, err := openskill.BalanceTeams(players, []int{2, 2}, objective, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		sum := func(team openskill.Team) float64 {
+			return team[0].AveragePlayerSkill + team[1].AveragePlayerSkill
+		}
+		if sum(teams[0]) != 50 || sum(teams[1]) != 50 {
+			t.Errorf("Objective %d: expected two teams with a skill of 50, got %v and %v", objective, sum(teams[0]), sum(teams[1]))
+		}
+	}
+
+	// larger pools are balanced with a local search, which must beat a split by skill.
+	players := newPlayers(40, 38, 35, 33, 31, 30, 29, 27, 26, 25, 24, 22, 21, 19, 17, 12)
+	teams, err := openskill.BalanceTeams(players, []int{4, 4, 4, 4}, openskill.MaximizeDraw, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	seen := map[*openskill.Rating]bool{}
+	for _, team := range teams {
+		if len(team) != 4 {
+			t.Errorf("Expected teams of 4 players, got %d", len(team))
+		}
+		for _, player := range team {
+			seen[player] = true
+		}
+	}
+	if len(seen) != len(players) {
+		t.Errorf("Expected every player to be in a team, got %d players", len(seen))
+	}
+
+	bySkill := []openskill.Team{players[0:4], players[4:8], players[8:12], players[12:16]}
+	if openskill.PredictDraw(teams, nil) <= openskill.PredictDraw(bySkill, nil) {
+		t.Errorf("Expected the balanced teams to be more likely to draw than teams split by skill")
+	}
+
+	if _, err := openskill.BalanceTeams(newPlayers(25, 25, 25), []int{2, 2}, openskill.MaximizeDraw, nil); !errors.Is(err, openskill.ErrBalanceSizes) {
+		t.Errorf("Expected %v, got %v", openskill.ErrBalanceSizes, err)
+	}
+}
+
+func TestBalanceTeamsIgnoresWeights(t *testing.T) {
+	players := []*openskill.Rating{}
+	for _, skill := range []float64{30, 28, 25, 24, 21, 18} {
+		players = append(players, openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: skill, SkillUncertaintyDegree: 2}, nil))
+	}
+
+	// the weights are set for a played game, not for the teams being formed.
+	weighted, err := openskill.BalanceTeams(players, []int{2, 2, 2}, openskill.MaximizeDraw, &openskill.Options{Weights: [][]float64{{1, 0.1}, {1, 1}, {0.1, 1}}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	plain, err := openskill.BalanceTeams(players, []int{2, 2, 2}, openskill.MaximizeDraw, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !reflect.DeepEqual(weighted, plain) {
+		t.Errorf("Expected the weights to be ignored, got %v and %v", weighted, plain)
+	}
+}
@@ -1,10 +1,6 @@
 package openskill
 
-import (
-	"math"
-
-	"github.com/samber/lo"
-)
+import "math"
 
 // BradleyTerryFull is a implementation of the Bradley-Terry ranking model
 // that uses full pairing. The Bradley-Terry model uses logistic distribution
@@ -15,43 +11,43 @@ func BradleyTerryFull(game []Team, options *Options) []Team {
 	epsilon := epsilon(options)
 	tbs := betaSq(options) * 2
 	_gamma := gamma(options)
+	_margin := marginFactor(options)
 
 	teamRatings := teamRatings(options)(game)
+	trace := newTracer(options, "BradleyTerryFull", teamRatings)
+
+	result := make([]Team, len(teamRatings))
 
-	return lo.Map(teamRatings, func(item *teamRating, index int) Team {
-		var iMu, iSigmaSq, iTeam, iRank = item.TeamMu, item.TeamSigmaSq, item.Team, item.Rank
+	for index, item := range teamRatings {
+		var iMu, iSigmaSq, iRank = item.TeamMu, item.TeamSigmaSq, item.Rank
+		var omegaSum, deltaSum float64
 
-		filteredRatings := lo.Filter(teamRatings, func(localItem *teamRating, localIndex int) bool {
-			return localIndex != index
-		})
+		for localIndex, localItem := range teamRatings {
+			if localIndex == index {
+				continue
+			}
 
-		_sums := lo.Reduce(filteredRatings, func(agg sums, localItem *teamRating, index int) sums {
 			var qMu, qSigmaSq, qRank = localItem.TeamMu, localItem.TeamSigmaSq, localItem.Rank
 
 			ciq := math.Sqrt(iSigmaSq + qSigmaSq + tbs)
 			piq := 1 / (1 + math.Exp((qMu-iMu)/ciq))
-
 			sigSqToCiq := iSigmaSq / ciq
-
 			iGamma := _gamma(ciq, int64(len(teamRatings)), item.TeamMu, item.TeamSigmaSq, item.Team, item.Rank)
 
-			agg.omegaSum += sigSqToCiq * (score(qRank, iRank) - piq)
-			agg.deltaSum += ((iGamma * sigSqToCiq) / ciq) * piq * (1 - piq)
+			margin := _margin(item, localItem)
+			omega := margin * sigSqToCiq * (score(qRank, iRank) - piq)
+			delta := ((iGamma * sigSqToCiq) / ciq) * piq * (1 - piq)
 
-			return agg
-		}, sums{omegaSum: 0, deltaSum: 0})
+			omegaSum += omega
+			deltaSum += delta
 
-		result := lo.Map([]*Rating(*iTeam), func(finalItem *Rating, index int) *Rating {
-			sigmaSq := math.Pow(finalItem.SkillUncertaintyDegree, 2)
-			mu := finalItem.AveragePlayerSkill + (sigmaSq/iSigmaSq)*_sums.omegaSum
-			sigma := finalItem.SkillUncertaintyDegree * math.Sqrt(math.Max(1-(sigmaSq/iSigmaSq)*_sums.deltaSum, epsilon))
+			trace.opponent(index, localItem, OpponentTrace{C: ciq, Gamma: iGamma, Margin: margin, Omega: omega, Delta: delta})
+		}
 
-			return &Rating{
-				AveragePlayerSkill:     mu,
-				SkillUncertaintyDegree: sigma,
-			}
-		})
+		trace.update(index, omegaSum, deltaSum)
+
+		result[index] = updateTeam(item, omegaSum, deltaSum, epsilon)
+	}
 
-		return Team(result)
-	})
+	return result
 }
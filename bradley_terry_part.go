@@ -1,10 +1,6 @@
 package openskill
 
-import (
-	"math"
-
-	"github.com/samber/lo"
-)
+import "math"
 
 // BradleyTerryPart is a implementation of the Bradley-Terry ranking model
 // that uses partial pairing. The Bradley-Terry model uses logistic distribution
@@ -17,42 +13,39 @@ func BradleyTerryPart(game []Team, options *Options) []Team {
 	epsilon := epsilon(options)
 	tbs := betaSq(options) * 2
 	_gamma := gamma(options)
+	_margin := marginFactor(options)
 
 	teamRatings := teamRatings(options)(game)
-	adjacentTeams := ladderPairs(teamRatings)
-
-	zipper := lo.Zip2(teamRatings, adjacentTeams)
+	trace := newTracer(options, "BradleyTerryPart", teamRatings)
 
-	return lo.Map(zipper, func(item lo.Tuple2[*teamRating, []*teamRating], index int) Team {
-		iTeamRating, iAdjacents := item.Unpack()
+	result := make([]Team, len(teamRatings))
 
-		var iMu, iSigmaSq, iTeam, iRank = iTeamRating.TeamMu, iTeamRating.TeamSigmaSq, iTeamRating.Team, iTeamRating.Rank
+	for index, item := range teamRatings {
+		var iMu, iSigmaSq, iRank = item.TeamMu, item.TeamSigmaSq, item.Rank
+		var omegaSum, deltaSum float64
 
-		_sums := lo.Reduce(iAdjacents, func(agg sums, localItem *teamRating, index int) sums {
+		adjacentTeams(teamRatings, index, func(localItem *teamRating) {
 			var qMu, qSigmaSq, qRank = localItem.TeamMu, localItem.TeamSigmaSq, localItem.Rank
 
 			ciq := math.Sqrt(iSigmaSq + qSigmaSq + tbs)
 			piq := 1 / (1 + math.Exp((qMu-iMu)/ciq))
 			sigSqToCiq := iSigmaSq / ciq
-			iGamma := _gamma(ciq, int64(len(teamRatings)), iTeamRating.TeamMu, iTeamRating.TeamSigmaSq, iTeamRating.Team, iTeamRating.Rank)
-
-			agg.omegaSum += sigSqToCiq * (score(qRank, iRank) - piq)
-			agg.deltaSum += ((iGamma * sigSqToCiq) / ciq) * piq * (1 - piq)
+			iGamma := _gamma(ciq, int64(len(teamRatings)), item.TeamMu, item.TeamSigmaSq, item.Team, item.Rank)
 
-			return agg
-		}, sums{omegaSum: 0, deltaSum: 0})
+			margin := _margin(item, localItem)
+			omega := margin * sigSqToCiq * (score(qRank, iRank) - piq)
+			delta := ((iGamma * sigSqToCiq) / ciq) * piq * (1 - piq)
 
-		result := lo.Map([]*Rating(*iTeam), func(finalItem *Rating, index int) *Rating {
-			sigmaSq := math.Pow(finalItem.SkillUncertaintyDegree, 2)
-			mu := finalItem.AveragePlayerSkill + (sigmaSq/iSigmaSq)*_sums.omegaSum
-			sigma := finalItem.SkillUncertaintyDegree * math.Sqrt(math.Max(1-(sigmaSq/iSigmaSq)*_sums.deltaSum, epsilon))
+			omegaSum += omega
+			deltaSum += delta
 
-			return &Rating{
-				AveragePlayerSkill:     mu,
-				SkillUncertaintyDegree: sigma,
-			}
+			trace.opponent(index, localItem, OpponentTrace{C: ciq, Gamma: iGamma, Margin: margin, Omega: omega, Delta: delta})
 		})
 
-		return Team(result)
-	})
+		trace.update(index, omegaSum, deltaSum)
+
+		result[index] = updateTeam(item, omegaSum, deltaSum, epsilon)
+	}
+
+	return result
 }
@@ -36,7 +36,24 @@ func epsilon(options *Options) float64 {
 	return 0.0001
 }
 
+func tauPerDay(options *Options) float64 {
+	if options != nil && options.TauPerDay != nil {
+		return *options.TauPerDay
+	}
+	return 0
+}
+
+func tieTolerance(options *Options) float64 {
+	if options != nil && options.TieTolerance != nil {
+		return *options.TieTolerance
+	}
+	return 0
+}
+
 func beta(options *Options) float64 {
+	if options != nil && options.Beta != nil {
+		return *options.Beta
+	}
 	return sigma(options) / 2
 }
 
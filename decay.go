@@ -0,0 +1,25 @@
+package openskill
+
+import (
+	"math"
+	"time"
+)
+
+// DecayRating returns the rating of a player after a period without playing, with its uncertainty
+// grown by Options.TauPerDay for each day of the period, so the rating of a player returning after
+// a long break adapts quickly to their current skill. Fractions of a day count proportionally. The
+// uncertainty grows up to Options.SkillUncertaintyDegree, and a rating that is already as uncertain
+// as that, as well as a non-positive elapsed time, is returned unchanged. The skill is never changed.
+func DecayRating(rating Rating, elapsed time.Duration, options *Options) Rating {
+	limit := sigma(options)
+	if elapsed <= 0 || rating.SkillUncertaintyDegree >= limit {
+		return rating
+	}
+
+	days := elapsed.Hours() / 24
+	tau := tauPerDay(options)
+
+	rating.SkillUncertaintyDegree = math.Min(math.Sqrt(rating.SkillUncertaintyDegree*rating.SkillUncertaintyDegree+days*tau*tau), limit)
+
+	return rating
+}
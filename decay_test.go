@@ -0,0 +1,81 @@
+package openskill_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+func TestDecayRating(t *testing.T) {
+	tau := 0.5
+	options := &openskill.Options{TauPerDay: &tau}
+	rating := openskill.Rating{AveragePlayerSkill: 30, SkillUncertaintyDegree: 2}
+
+	decayed := openskill.DecayRating(rating, 10*24*time.Hour, options)
+	if decayed.AveragePlayerSkill != 30 {
+		t.Errorf("Expected the skill to be unchanged, got %v", decayed.AveragePlayerSkill)
+	}
+	if expected := math.Sqrt(4 + 10*0.25); math.Abs(decayed.SkillUncertaintyDegree-expected) > 1e-12 {
+		t.Errorf("Expected an uncertainty of %v, got %v", expected, decayed.SkillUncertaintyDegree)
+	}
+
+	// half a day counts as half of the growth of a day.
+	half := openskill.DecayRating(rating, 12*time.Hour, options)
+	if expected := math.Sqrt(4 + 0.5*0.25); math.Abs(half.SkillUncertaintyDegree-expected) > 1e-12 {
+		t.Errorf("Expected an uncertainty of %v, got %v", expected, half.SkillUncertaintyDegree)
+	}
+
+	capped := openskill.DecayRating(rating, 10000*24*time.Hour, options)
+	if capped.SkillUncertaintyDegree != 25.0/3 {
+		t.Errorf("Expected the uncertainty to be capped at the initial one, got %v", capped.SkillUncertaintyDegree)
+	}
+
+	uncertain := openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: 10}
+	for _, test := range []struct {
+		rating  openskill.Rating
+		elapsed time.Duration
+		options *openskill.Options
+	}{
+		{rating, -time.Hour, options},
+		{rating, 10 * 24 * time.Hour, nil},
+		{uncertain, time.Hour, options},
+	} {
+		if decayed := openskill.DecayRating(test.rating, test.elapsed, test.options); decayed != test.rating {
+			t.Errorf("Expected %v to be unchanged, got %v", test.rating, decayed)
+		}
+	}
+
+	if _, err := openskill.NewRater(openskill.WithTauPerDay(-1)); err == nil {
+		t.Errorf("Expected a negative TauPerDay to be rejected")
+	}
+}
+
+func TestReplayDecay(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	log := &openskill.MatchLog[string]{}
+	log.Append([][]string{{"alice"}, {"bob"}}, nil, start)
+	log.Append([][]string{{"alice"}, {"bob"}}, nil, start.Add(100*24*time.Hour))
+
+	tau := 0.1
+	decayed, err := openskill.Replay(log, &openskill.Options{TauPerDay: &tau}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := openskill.Replay(log, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decayed.Snapshots[0].Ratings["alice"] != plain.Snapshots[0].Ratings["alice"] {
+		t.Errorf("Expected the first match to be unaffected by decay")
+	}
+	if decayed.Ratings["alice"].SkillUncertaintyDegree <= plain.Ratings["alice"].SkillUncertaintyDegree {
+		t.Errorf("Expected the inactivity to grow the uncertainty, got %v and %v", decayed.Ratings["alice"], plain.Ratings["alice"])
+	}
+	if decayed.Ratings["alice"].AveragePlayerSkill-decayed.Snapshots[0].Ratings["alice"].AveragePlayerSkill <= plain.Ratings["alice"].AveragePlayerSkill-plain.Snapshots[0].Ratings["alice"].AveragePlayerSkill {
+		t.Errorf("Expected a returning player to move faster")
+	}
+}
@@ -0,0 +1,58 @@
+package openskill
+
+import "math"
+
+// skillRatingMax is the highest value of the SkillRatingScale.
+const skillRatingMax = 5000.0
+
+// DisplayScale maps the Ordinal of a rating to a value that is shown to players.
+type DisplayScale func(ordinal float64) float64
+
+// ordinalRange returns the Ordinal of a new player, mu - z * sigma, and its mirror, mu + z * sigma,
+// which is the Ordinal of a player whose skill is z new player deviations above the average. It is
+// the range that display scales are anchored to, so they move along with the constants.
+func ordinalRange(options *Options) (float64, float64) {
+	spread := z(options) * sigma(options)
+	return mu(options) - spread, mu(options) + spread
+}
+
+// LinearScale returns a scale that maps the ordinal range [mu - z * sigma, mu + z * sigma], with
+// the constants of a new player, linearly onto [low, high]. A new player is shown as low, and as
+// their uncertainty drops, an average player moves to the middle of the range. Values outside of
+// the range are not clamped.
+func LinearScale(low, high float64, options *Options) DisplayScale {
+	from, to := ordinalRange(options)
+
+	return func(ordinal float64) float64 {
+		return low + (ordinal-from)*(high-low)/(to-from)
+	}
+}
+
+// SkillRatingScale returns a scale from 0 to 5000, like LinearScale(0, 5000, options), with the
+// values clamped to that range.
+func SkillRatingScale(options *Options) DisplayScale {
+	scale := LinearScale(0, skillRatingMax, options)
+
+	return func(ordinal float64) float64 {
+		return math.Min(math.Max(scale(ordinal), 0), skillRatingMax)
+	}
+}
+
+// EloScale returns a scale that shows the Ordinal in Elo points, converted the same way as
+// RatingToElo, which makes it a conservative Elo rating: an average player is shown at 1500
+// once their uncertainty is gone, and below that while it is not.
+func EloScale(options *Options) DisplayScale {
+	return func(ordinal float64) float64 {
+		return RatingToElo(Rating{AveragePlayerSkill: ordinal}, options)
+	}
+}
+
+// DisplayRating returns the value of a rating on a display scale. When scale is nil, the
+// SkillRatingScale is used.
+func DisplayRating(rating Rating, scale DisplayScale, options *Options) float64 {
+	if scale == nil {
+		scale = SkillRatingScale(options)
+	}
+
+	return scale(Ordinal(rating, options))
+}
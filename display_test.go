@@ -0,0 +1,107 @@
+package openskill_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+func TestDisplayScales(t *testing.T) {
+	newPlayer := *openskill.NewRating(nil, nil)
+	settled := openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: 0}
+
+	for _, test := range []struct {
+		name     string
+		scale    openskill.DisplayScale
+		rating   openskill.Rating
+		expected float64
+	}{
+		{"skill rating of a new player", nil, newPlayer, 0},
+		{"skill rating of a settled average player", nil, settled, 2500},
+		{"skill rating above the range", nil, openskill.Rating{AveragePlayerSkill: 80, SkillUncertaintyDegree: 1}, 5000},
+		{"linear scale of a new player", openskill.LinearScale(100, 200, nil), newPlayer, 100},
+		{"linear scale of a settled average player", openskill.LinearScale(100, 200, nil), settled, 150},
+		{"linear scale below the range", openskill.LinearScale(100, 200, nil), openskill.Rating{AveragePlayerSkill: 0, SkillUncertaintyDegree: 5}, 70},
+		{"elo of a settled average player", openskill.EloScale(nil), settled, 1500},
+		{"elo of a new player", openskill.EloScale(nil), newPlayer, 300},
+	} {
+		if value := openskill.DisplayRating(test.rating, test.scale, nil); math.Abs(value-test.expected) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, value)
+		}
+	}
+
+	// the scales follow the constants.
+	z := 2.0
+	options := &openskill.Options{StandardizedPlayerSkill: &z}
+	if value := openskill.DisplayRating(*openskill.NewRating(nil, options), nil, options); value != 0 {
+		t.Errorf("Expected a new player to be shown at 0, got %v", value)
+	}
+}
+
+// withValue returns a rating shown at the given value on the SkillRatingScale.
+func withValue(value float64) openskill.Rating {
+	return openskill.Rating{AveragePlayerSkill: value / 100, SkillUncertaintyDegree: 0}
+}
+
+func TestLadder(t *testing.T) {
+	ladder, err := openskill.NewLadder(openskill.LadderConfig{
+		Tiers: []openskill.Tier{
+			{Name: "Bronze", MinValue: 0, Divisions: 2},
+			{Name: "Silver", MinValue: 1000, Divisions: 2},
+			{Name: "Gold", MinValue: 2000},
+		},
+		MaxValue:         3000,
+		Hysteresis:       100,
+		PlacementMatches: 5,
+	}, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	placing := ladder.Standing(withValue(1600), 3, nil)
+	if placing.Placed || placing.PlacementsLeft != 2 {
+		t.Fatalf("Expected the player to have 2 placement matches left, got %+v", placing)
+	}
+
+	standing := ladder.Standing(withValue(1600), 5, &placing)
+	if !standing.Placed || standing.Name != "Silver" || standing.Division != 1 {
+		t.Fatalf("Expected the player to be placed on Silver 1, got %+v", standing)
+	}
+
+	for _, step := range []struct {
+		value    float64
+		name     string
+		division int
+	}{
+		// within the hysteresis, the player holds Silver 1.
+		{1450, "Silver", 1},
+		// past it, they drop to where the hysteresis allows.
+		{1350, "Silver", 2},
+		{950, "Silver", 2},
+		{850, "Bronze", 1},
+		// promotions are immediate.
+		{1000, "Silver", 2},
+		{2500, "Gold", 1},
+		{9000, "Gold", 1},
+		{-50, "Bronze", 2},
+	} {
+		standing = ladder.Standing(withValue(step.value), 10, &standing)
+		if standing.Name != step.name || standing.Division != step.division {
+			t.Errorf("Expected %v to be %s %d, got %s %d", step.value, step.name, step.division, standing.Name, standing.Division)
+		}
+	}
+
+	for _, config := range []openskill.LadderConfig{
+		{},
+		{Tiers: []openskill.Tier{{MinValue: 10}, {MinValue: 5}}, MaxValue: 20},
+		{Tiers: []openskill.Tier{{MinValue: 10}}, MaxValue: 5},
+		{Tiers: []openskill.Tier{{MinValue: 0, Divisions: -1}}, MaxValue: 5},
+		{Tiers: []openskill.Tier{{MinValue: 0}}, MaxValue: 5, Hysteresis: -1},
+	} {
+		if _, err := openskill.NewLadder(config, nil); !errors.Is(err, openskill.ErrInvalidLadder) {
+			t.Errorf("Expected ErrInvalidLadder for %+v, got %v", config, err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package openskill
+
+import (
+	"math"
+
+	"github.com/samber/lo"
+)
+
+// eloBase is the Elo rating that corresponds to the average skill of a new player.
+const eloBase = 1500.0
+
+// eloDeviation is the standard deviation, in Elo points, of the performance of a player
+// on Elo's original formulation, which is the one behind the FIDE rating tables.
+const eloDeviation = 200.0
+
+// eloScale is the difference in Elo points for which the expected score of the stronger player
+// is ten times the one of the weaker player, on the logistic curve used by the Elo model.
+const eloScale = 400.0
+
+func eloKFactor(options *Options) float64 {
+	if options != nil && options.EloKFactor != nil {
+		return *options.EloKFactor
+	}
+	return 32
+}
+
+// RatingToElo converts a Rating to an Elo rating. The conversion maps the average skill of a new
+// player to 1500 Elo points and the performance deviation, beta, to 200 Elo points, which is the
+// performance deviation of a player on Elo's original formulation. Under this mapping, the win
+// probability given by PredictWin to two players with a small uncertainty is the same as the
+// expected score given by Elo's original normal curve, which the logistic curve of the Elo model
+// approximates within 0.015. The skill uncertainty is not taken into consideration.
+func RatingToElo(rating Rating, options *Options) float64 {
+	return eloBase + (rating.AveragePlayerSkill-mu(options))*eloDeviation/beta(options)
+}
+
+// EloToRating converts an Elo rating to a Rating, with the default uncertainty for a new player.
+// It is the inverse of RatingToElo. Legacy Elo ratings carry no uncertainty, so the uncertainty
+// of the returned rating can be lowered for players with a long history.
+func EloToRating(elo float64, options *Options) *Rating {
+	return &Rating{
+		AveragePlayerSkill:     mu(options) + (elo-eloBase)*beta(options)/eloDeviation,
+		SkillUncertaintyDegree: sigma(options),
+	}
+}
+
+// eloExpectedScore returns the expected score of a player against another, given their Elo ratings,
+// on the logistic curve 1 / (1 + 10^(-difference/400)) used by FIDE and most Elo implementations.
+func eloExpectedScore(elo, opponentElo float64) float64 {
+	return 1 / (1 + math.Pow(10, -(elo-opponentElo)/eloScale))
+}
+
+// Elo is an implementation of the Elo rating system. Each team is rated as a single player with
+// the average Elo rating of its members, whose expected score is given by the classic logistic
+// curve, and every player of a team receives the change of the team, scaled by their weight. With
+// more than two teams, each team plays against every other one, and the change is averaged over
+// its opponents. The constant K is set through Options.EloKFactor.
+// The uncertainty of the ratings is left untouched. See RatingToElo for how ratings are mapped
+// to Elo points. This function accepts the a slice with the team that are competing, plus an
+// options parameter, with things such as scores and previous rankings. The function return a
+// slice of teams that are properly ranked.
+func Elo(game []Team, options *Options) []Team {
+	k := eloKFactor(options)
+	teamRatings := teamRatings(options)(game)
+
+	teamElos := lo.Map(teamRatings, func(item *teamRating, index int) float64 {
+		return lo.Sum(lo.Map([]*Rating(*item.Team), func(rating *Rating, index int) float64 {
+			return RatingToElo(*rating, options)
+		})) / float64(len(*item.Team))
+	})
+
+	return lo.Map(teamRatings, func(item *teamRating, index int) Team {
+		var change float64
+
+		for localIndex, localItem := range teamRatings {
+			if localIndex == index {
+				continue
+			}
+			change += k * (score(localItem.Rank, item.Rank) - eloExpectedScore(teamElos[index], teamElos[localIndex]))
+		}
+
+		if len(teamRatings) > 1 {
+			change /= float64(len(teamRatings) - 1)
+		}
+
+		return lo.Map([]*Rating(*item.Team), func(rating *Rating, localIndex int) *Rating {
+			elo := RatingToElo(*rating, options) + item.Weights[localIndex]*change
+			updated := EloToRating(elo, options)
+			updated.SkillUncertaintyDegree = rating.SkillUncertaintyDegree
+			updated.Volatility = rating.Volatility
+
+			return updated
+		})
+	})
+}
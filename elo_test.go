@@ -0,0 +1,75 @@
+package openskill_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+func TestEloConversion(t *testing.T) {
+	mu := 1000.0
+	custom := openskill.Options{AveragePlayerSkill: &mu}
+	model := openskill.Model(openskill.Elo)
+	k := 1.0
+
+	for _, options := range []*openskill.Options{nil, &custom} {
+		for _, pair := range [][2]float64{{1500, 1500}, {1700, 1500}, {1200, 2100}, {2400, 2350}} {
+			a, b := openskill.EloToRating(pair[0], options), openskill.EloToRating(pair[1], options)
+
+			if elo := openskill.RatingToElo(*a, options); !withinTolerance(pair[0], elo, 1e-12) {
+				t.Errorf("Expected %f to round trip, got %f", pair[0], elo)
+			}
+			if rating := openskill.EloToRating(openskill.RatingToElo(*b, options), options); !withinTolerance(b.AveragePlayerSkill, rating.AveragePlayerSkill, 1e-12) {
+				t.Errorf("Expected %f to round trip, got %f", b.AveragePlayerSkill, rating.AveragePlayerSkill)
+			}
+
+			// with a K factor of 1, the winner gains 1 minus their expected score.
+			rateOptions := openskill.Options{Model: &model, EloKFactor: &k}
+			if options != nil {
+				rateOptions.AveragePlayerSkill = options.AveragePlayerSkill
+			}
+			result := openskill.Rate([]openskill.Team{openskill.NewTeam(a), openskill.NewTeam(b)}, rateOptions)
+
+			expected := 1 / (1 + math.Pow(10, -(pair[0]-pair[1])/400))
+			if score := 1 - (openskill.RatingToElo(*result[0][0], options) - pair[0]); !withinTolerance(expected, score, 1e-9) {
+				t.Errorf("Expected %v to score %f, got %f", pair, expected, score)
+			}
+		}
+	}
+}
+
+func TestElo(t *testing.T) {
+	model := openskill.Model(openskill.Elo)
+
+	teams := []openskill.Team{
+		openskill.NewTeam(openskill.EloToRating(1500, nil)),
+		openskill.NewTeam(openskill.EloToRating(1500, nil)),
+	}
+
+	result := openskill.Rate(teams, openskill.Options{Model: &model, Rankings: []int64{2, 1}})
+
+	if elo := openskill.RatingToElo(*result[0][0], nil); !withinTolerance(1484, elo, 1e-12) {
+		t.Errorf("Expected the loser to drop to 1484, got %f", elo)
+	}
+	if elo := openskill.RatingToElo(*result[1][0], nil); !withinTolerance(1516, elo, 1e-12) {
+		t.Errorf("Expected the winner to climb to 1516, got %f", elo)
+	}
+	if result[0][0].SkillUncertaintyDegree != teams[0][0].SkillUncertaintyDegree {
+		t.Errorf("Expected the uncertainty to be untouched, got %f", result[0][0].SkillUncertaintyDegree)
+	}
+
+	// a 200 points favourite expects to score 1 / (1 + 10^(-200/400)) on the logistic curve.
+	favourite := []openskill.Team{
+		openskill.NewTeam(openskill.EloToRating(1700, nil)),
+		openskill.NewTeam(openskill.EloToRating(1500, nil)),
+	}
+	upset := openskill.Rate(favourite, openskill.Options{Model: &model, Rankings: []int64{2, 1}})
+	expected := 1 / (1 + math.Pow(10, -0.5))
+	if elo := openskill.RatingToElo(*upset[0][0], nil); !withinTolerance(1700-32*expected, elo, 1e-9) {
+		t.Errorf("Expected the favourite to drop to %f, got %f", 1700-32*expected, elo)
+	}
+	if elo := openskill.RatingToElo(*upset[1][0], nil); !withinTolerance(1500+32*expected, elo, 1e-9) {
+		t.Errorf("Expected the underdog to climb to %f, got %f", 1500+32*expected, elo)
+	}
+}
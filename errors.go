@@ -0,0 +1,57 @@
+package openskill
+
+import "errors"
+
+var (
+	// ErrNoTeams is returned when there are no teams to be rated.
+	ErrNoTeams = errors.New("openskill: no teams were provided")
+
+	// ErrEmptyTeam is returned when one of the teams has no players.
+	ErrEmptyTeam = errors.New("openskill: team has no players")
+
+	// ErrNilRating is returned when a team contains a nil *Rating.
+	ErrNilRating = errors.New("openskill: team contains a nil rating")
+
+	// ErrInvalidMu is returned when a rating has an average skill that is NaN or infinite.
+	ErrInvalidMu = errors.New("openskill: average player skill must be a finite number")
+
+	// ErrInvalidSigma is returned when a rating has a skill uncertainty that is NaN, infinite, zero or negative.
+	ErrInvalidSigma = errors.New("openskill: skill uncertainty degree must be a positive finite number")
+
+	// ErrRankingsLength is returned when Options.Rankings is set and its length differs from the amount of teams.
+	ErrRankingsLength = errors.New("openskill: length of rankings does not match the amount of teams")
+
+	// ErrScoresLength is returned when Options.Scores is set and its length differs from the amount of teams.
+	ErrScoresLength = errors.New("openskill: length of scores does not match the amount of teams")
+
+	// ErrInvalidOutcome is returned when Options.FloatRankings or Options.FloatScores contain NaN or infinite values.
+	ErrInvalidOutcome = errors.New("openskill: rankings and scores must be finite numbers")
+
+	// ErrWeightsLength is returned when Options.Weights is set and its shape differs from the teams being rated.
+	ErrWeightsLength = errors.New("openskill: shape of weights does not match the teams")
+
+	// ErrInvalidWeight is returned when a weight is NaN, infinite or negative, or when every weight of a team is zero.
+	ErrInvalidWeight = errors.New("openskill: weights must be non-negative finite numbers with at least one positive weight per team")
+
+	// ErrDuplicatePlayer is returned when the same player identifier shows up more than once in a match.
+	ErrDuplicatePlayer = errors.New("openskill: player is present more than once")
+
+	// ErrBalanceSizes is returned by BalanceTeams when the team sizes do not add up to the amount of players.
+	ErrBalanceSizes = errors.New("openskill: team sizes do not match the amount of players")
+
+	// ErrInvalidSeasonReset is returned by SeasonReset when one of its parameters is out of its valid range.
+	ErrInvalidSeasonReset = errors.New("openskill: invalid season reset parameters")
+
+	// ErrInvalidLadder is returned by NewLadder when the tiers or the other settings of a ladder are out of their valid range.
+	ErrInvalidLadder = errors.New("openskill: invalid ladder")
+
+	// ErrNotSerializable is returned when a MatchLog is serialized with options holding a custom
+	// Model or GammaFunction, or deserialized with a model that is not one of this package.
+	ErrNotSerializable = errors.New("openskill: options cannot be serialized")
+
+	// ErrNilModel is returned when Options.Model points to a nil function.
+	ErrNilModel = errors.New("openskill: model is nil")
+
+	// ErrInvalidOption is returned when one of the constants set on Options is out of its valid range.
+	ErrInvalidOption = errors.New("openskill: invalid option")
+)
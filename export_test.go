@@ -0,0 +1,4 @@
+package openskill
+
+// CloneOptions exposes cloneOptions to the tests.
+var CloneOptions = cloneOptions
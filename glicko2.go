@@ -0,0 +1,273 @@
+package openskill
+
+import (
+	"math"
+
+	"github.com/samber/lo"
+)
+
+// glicko2Scale is the constant used by Glicko-2 to convert ratings to its internal scale.
+const glicko2Scale = 173.7178
+
+// glicko2Convergence is the tolerance of the iterative procedure that finds the new volatility.
+const glicko2Convergence = 0.000001
+
+func volatility(options *Options) float64 {
+	if options != nil && options.Volatility != nil {
+		return *options.Volatility
+	}
+	return 0.06
+}
+
+func volatilityConstraint(options *Options) float64 {
+	if options != nil && options.VolatilityConstraint != nil {
+		return *options.VolatilityConstraint
+	}
+	return 0.5
+}
+
+// glicko2Unit returns how many units of the Glicko-2 internal scale are in a unit of this package
+// scale. It is chosen so the default uncertainty of a player maps to the default rating deviation
+// of Glicko-2, which is 350 on the Glicko scale.
+func glicko2Unit(options *Options) float64 {
+	return sigma(options) / (350 / glicko2Scale)
+}
+
+// glicko2Player is a rating on the Glicko-2 internal scale.
+type glicko2Player struct {
+	mu         float64
+	phi        float64
+	volatility float64
+}
+
+// glicko2Result is the outcome of a game of a player against an opponent, where the score is
+// 1 for a win, 0.5 for a draw and 0 for a loss.
+type glicko2Result struct {
+	opponent glicko2Player
+	score    float64
+}
+
+func toGlicko2(rating *Rating, options *Options) glicko2Player {
+	unit := glicko2Unit(options)
+
+	return glicko2Player{
+		mu:         (rating.AveragePlayerSkill - mu(options)) / unit,
+		phi:        rating.SkillUncertaintyDegree / unit,
+		volatility: lo.Ternary(rating.Volatility > 0, rating.Volatility, volatility(options)),
+	}
+}
+
+func fromGlicko2(player glicko2Player, options *Options) Rating {
+	unit := glicko2Unit(options)
+
+	return Rating{
+		AveragePlayerSkill:     player.mu*unit + mu(options),
+		SkillUncertaintyDegree: player.phi * unit,
+		Volatility:             player.volatility,
+	}
+}
+
+func glicko2G(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+func glicko2E(mu, opponentMu, opponentPhi float64) float64 {
+	return 1 / (1 + math.Exp(-glicko2G(opponentPhi)*(mu-opponentMu)))
+}
+
+// glicko2Update applies the Glicko-2 algorithm to a player over all the results of a rating period.
+func glicko2Update(player glicko2Player, results []glicko2Result, constraint float64) glicko2Player {
+	if len(results) == 0 {
+		player.phi = math.Sqrt(player.phi*player.phi + player.volatility*player.volatility)
+		return player
+	}
+
+	var vInv, deltaSum float64
+
+	for _, result := range results {
+		g := glicko2G(result.opponent.phi)
+		e := glicko2E(player.mu, result.opponent.mu, result.opponent.phi)
+
+		vInv += g * g * e * (1 - e)
+		deltaSum += g * (result.score - e)
+	}
+
+	v := 1 / vInv
+	delta := v * deltaSum
+
+	phiSq := player.phi * player.phi
+	a := math.Log(player.volatility * player.volatility)
+	tauSq := constraint * constraint
+
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		return (ex*(delta*delta-phiSq-v-ex))/(2*math.Pow(phiSq+v+ex, 2)) - (x-a)/tauSq
+	}
+
+	// Illinois algorithm, as in step 5 of the Glicko-2 paper.
+	A := a
+	var B float64
+	if delta*delta > phiSq+v {
+		B = math.Log(delta*delta - phiSq - v)
+	} else {
+		k := 1.0
+		for f(a-k*constraint) < 0 {
+			k++
+		}
+		B = a - k*constraint
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > glicko2Convergence {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA = fA / 2
+		}
+		B, fB = C, fC
+	}
+
+	newVolatility := math.Exp(A / 2)
+	phiStar := math.Sqrt(phiSq + newVolatility*newVolatility)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+
+	return glicko2Player{
+		mu:         player.mu + newPhi*newPhi*deltaSum,
+		phi:        newPhi,
+		volatility: newVolatility,
+	}
+}
+
+// glicko2Results returns the results of each player of a match, given the rank of each team. Every
+// player plays against the other teams, each of them represented by a single player with the average
+// skill of its members and the root mean square of their uncertainties.
+func glicko2Results(game []Team, ranks []int64, options *Options) [][][]glicko2Result {
+	opponents := lo.Map(game, func(item Team, index int) glicko2Player {
+		players := lo.Map([]*Rating(item), func(rating *Rating, index int) glicko2Player {
+			return toGlicko2(rating, options)
+		})
+		size := float64(len(players))
+
+		return glicko2Player{
+			mu: lo.Sum(lo.Map(players, func(player glicko2Player, index int) float64 {
+				return player.mu
+			})) / size,
+			phi: math.Sqrt(lo.Sum(lo.Map(players, func(player glicko2Player, index int) float64 {
+				return player.phi * player.phi
+			})) / size),
+		}
+	})
+
+	return lo.Map(game, func(item Team, index int) [][]glicko2Result {
+		var results []glicko2Result
+
+		for localIndex := range game {
+			if localIndex == index {
+				continue
+			}
+			results = append(results, glicko2Result{opponent: opponents[localIndex], score: score(ranks[localIndex], ranks[index])})
+		}
+
+		return lo.Map([]*Rating(item), func(rating *Rating, index int) []glicko2Result {
+			return results
+		})
+	})
+}
+
+// Glicko2 is an implementation of the Glicko-2 rating system, treating the game as a rating period
+// of its own. Ratings are converted to and from the Glicko-2 scale so that the default uncertainty
+// of this package maps to the default Glicko-2 rating deviation, which keeps the results usable
+// with the prediction functions. The volatility of each player is kept in Rating.Volatility.
+// Teams are supported by having each player face every other team as if it was a single player.
+// Use a RatingPeriod to rate many games at once, as Glicko-2 intends. This function accepts the a
+// slice with the team that are competing, plus an options parameter, with things such as scores
+// and previous rankings. The function return a slice of teams that are properly ranked.
+func Glicko2(game []Team, options *Options) []Team {
+	constraint := volatilityConstraint(options)
+	ranks := lo.Map(teamRatings(options)(game), func(item *teamRating, index int) int64 {
+		return item.Rank
+	})
+	results := glicko2Results(game, ranks, options)
+
+	return lo.Map(game, func(item Team, index int) Team {
+		return lo.Map([]*Rating(item), func(rating *Rating, localIndex int) *Rating {
+			updated := fromGlicko2(glicko2Update(toGlicko2(rating, options), results[index][localIndex], constraint), options)
+			return &updated
+		})
+	})
+}
+
+// RatingPeriod collects the games played by a set of players during a period of time, to be rated
+// all at once with Glicko-2 when the period is closed. The ratings are not changed while the
+// period is open, as every game of a period is rated against the ratings from its beginning.
+type RatingPeriod struct {
+	options Options
+	players []*Rating
+	results map[*Rating][]glicko2Result
+}
+
+// NewRatingPeriod creates an empty rating period with a set of constants.
+func NewRatingPeriod(options *Options) *RatingPeriod {
+	period := &RatingPeriod{results: make(map[*Rating][]glicko2Result)}
+
+	if options != nil {
+		period.options = *options
+	}
+
+	return period
+}
+
+// Register adds players to the rating period. Players that are registered but do not play any
+// game have their uncertainty increased when the period is closed. Players are identified by
+// their pointer, and the ones that play a game are registered automatically.
+func (p *RatingPeriod) Register(players ...*Rating) {
+	for _, player := range players {
+		if _, ok := p.results[player]; !ok {
+			p.results[player] = []glicko2Result{}
+			p.players = append(p.players, player)
+		}
+	}
+}
+
+// AddGame adds the outcome of a game to the rating period. A nil outcome means the teams are
+// ranked in the order they were provided. The input is validated just like RateE does.
+func (p *RatingPeriod) AddGame(teams []Team, outcome *Outcome) error {
+	options := outcomeOptions(p.options, outcome)
+
+	if err := validate(teams, &options); err != nil {
+		return err
+	}
+	if err := validateMargin(&options, Glicko2); err != nil {
+		return err
+	}
+
+	ranks := denseRanks(outcomeOrder(&options, len(teams)), tieTolerance(&options))
+
+	results := glicko2Results(teams, ranks, &options)
+
+	for i, team := range teams {
+		p.Register(team...)
+
+		for j, player := range team {
+			p.results[player] = append(p.results[player], results[i][j]...)
+		}
+	}
+
+	return nil
+}
+
+// Close rates every registered player with all of the games of the rating period, returning the
+// new ratings keyed by the same pointers used to register them. The period is left untouched.
+func (p *RatingPeriod) Close() map[*Rating]Rating {
+	constraint := volatilityConstraint(&p.options)
+
+	ratings := make(map[*Rating]Rating)
+
+	for _, player := range p.players {
+		ratings[player] = fromGlicko2(glicko2Update(toGlicko2(player, &p.options), p.results[player], constraint), &p.options)
+	}
+
+	return ratings
+}
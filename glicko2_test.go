@@ -0,0 +1,98 @@
+package openskill_test
+
+import (
+	"testing"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+func TestGlicko2RatingPeriod(t *testing.T) {
+	// The example from the Glicko-2 paper, by Mark Glickman, converted from the Glicko scale,
+	// where the default rating deviation of 350 maps to the default uncertainty of 25 / 3.
+	unit := (25.0 / 3) / 350
+	fromGlicko := func(rating, deviation float64) *openskill.Rating {
+		return &openskill.Rating{AveragePlayerSkill: 25 + (rating-1500)*unit, SkillUncertaintyDegree: deviation * unit, Volatility: 0.06}
+	}
+
+	player := fromGlicko(1500, 200)
+	idle := fromGlicko(1500, 200)
+
+	period := openskill.NewRatingPeriod(nil)
+	period.Register(idle)
+
+	games := []struct {
+		opponent *openskill.Rating
+		rankings []int64
+	}{
+		{fromGlicko(1400, 30), []int64{1, 2}},
+		{fromGlicko(1550, 100), []int64{2, 1}},
+		{fromGlicko(1700, 300), []int64{2, 1}},
+	}
+
+	for _, game := range games {
+		err := period.AddGame([]openskill.Team{openskill.NewTeam(player), openskill.NewTeam(game.opponent)}, &openskill.Outcome{Rankings: game.rankings})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	ratings := period.Close()
+
+	expected := fromGlicko(1464.06, 151.52)
+	result := ratings[player]
+	if !withinTolerance(expected.AveragePlayerSkill, result.AveragePlayerSkill, 1e-5) || !withinTolerance(expected.SkillUncertaintyDegree, result.SkillUncertaintyDegree, 1e-4) || !withinTolerance(0.05999, result.Volatility, 1e-4) {
+		t.Errorf("Expected %v, got %v", *expected, result)
+	}
+
+	if *player != *fromGlicko(1500, 200) {
+		t.Errorf("Expected the rating period to leave the ratings untouched, got %v", *player)
+	}
+
+	if ratings[idle].AveragePlayerSkill != idle.AveragePlayerSkill || ratings[idle].SkillUncertaintyDegree <= idle.SkillUncertaintyDegree {
+		t.Errorf("Expected an idle player to only grow uncertain, got %v", ratings[idle])
+	}
+
+	model := openskill.Model(openskill.Glicko2)
+	rated := openskill.Rate([]openskill.Team{openskill.NewTeam(fromGlicko(1500, 200)), openskill.NewTeam(fromGlicko(1400, 30))}, openskill.Options{Model: &model})
+	single := openskill.NewRatingPeriod(nil)
+	_ = single.AddGame([]openskill.Team{openskill.NewTeam(player), openskill.NewTeam(games[0].opponent)}, nil)
+	if *rated[0][0] != single.Close()[player] {
+		t.Errorf("Expected the Glicko2 model to rate a game as a rating period of its own, got %v", *rated[0][0])
+	}
+}
+
+func TestVolatilityWithTau(t *testing.T) {
+	tau := 0.1
+
+	for name, model := range map[string]openskill.Model{
+		"Glicko2":      openskill.Glicko2,
+		"PlackettLuce": openskill.PlackettLuce,
+		"TrueSkill":    openskill.TrueSkill,
+		"Elo":          openskill.Elo,
+	} {
+		model := model
+		teams := []openskill.Team{
+			openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: 5, Volatility: 0.09}),
+			openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 28, SkillUncertaintyDegree: 4, Volatility: 0.03}),
+		}
+
+		rated := openskill.Rate(teams, openskill.Options{Model: &model, Tau: &tau})
+
+		switch name {
+		case "Glicko2":
+			// the volatility changes, but starting from the stored one, not from the default.
+			defaults := openskill.Rate([]openskill.Team{
+				openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: 5}),
+				openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 28, SkillUncertaintyDegree: 4}),
+			}, openskill.Options{Model: &model, Tau: &tau})
+
+			if rated[0][0].Volatility < 0.085 || rated[0][0].Volatility == defaults[0][0].Volatility {
+				t.Errorf("%s: expected the volatility to start from 0.09, got %v", name, rated[0][0].Volatility)
+			}
+		default:
+			if rated[0][0].Volatility != 0.09 || rated[1][0].Volatility != 0.03 {
+				t.Errorf("%s: expected the volatility to be kept, got %v and %v", name, rated[0][0].Volatility, rated[1][0].Volatility)
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package openskill
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Tier is a named band of a Ladder, such as Gold, split into divisions of equal width.
+type Tier struct {
+	Name string
+
+	// MinValue is the display value a player needs to enter the tier.
+	MinValue float64
+
+	// Divisions is how many divisions the tier is split into. When 0, the tier has a single division.
+	Divisions int
+}
+
+// LadderConfig holds the tiers of a Ladder and how players move between them.
+type LadderConfig struct {
+	// Tiers holds the tiers from the lowest to the highest. Players below the first tier are
+	// placed on its lowest division.
+	Tiers []Tier
+
+	// MaxValue is the upper bound of the highest tier, used to split it into divisions. Players
+	// above it stay on the highest division.
+	MaxValue float64
+
+	// Hysteresis is how far, in display points, a player must drop below the start of their
+	// division to be demoted, so players close to a boundary don't bounce between divisions.
+	// Promotions happen as soon as the start of a higher division is reached.
+	Hysteresis float64
+
+	// PlacementMatches is how many games a player must play before being placed on the ladder.
+	PlacementMatches int
+
+	// Scale is the display scale the tiers are defined on. When nil, the SkillRatingScale is used.
+	Scale DisplayScale
+}
+
+// Standing is the position of a player on a Ladder.
+type Standing struct {
+	// Placed tells whether the player finished their placement matches. When it is false, only
+	// Value and PlacementsLeft are set.
+	Placed bool
+
+	// PlacementsLeft is how many placement matches the player still has to play.
+	PlacementsLeft int
+
+	// Tier is the index of the tier of the player on LadderConfig.Tiers.
+	Tier int
+
+	// Name is the name of the tier of the player.
+	Name string
+
+	// Division is the division of the player on their tier, where 1 is the highest, as in most games.
+	Division int
+
+	// Value is the display value of the rating of the player.
+	Value float64
+}
+
+// Ladder places players on tiers and divisions according to the display value of their rating.
+type Ladder struct {
+	config  LadderConfig
+	options *Options
+
+	// starts holds the lowest display value of every division, from the lowest to the highest,
+	// and tierStarts the index of the first division of each tier on it.
+	starts     []float64
+	tierStarts []int
+}
+
+// NewLadder creates a ladder. It returns an error wrapping ErrInvalidLadder when there are no tiers,
+// when the tiers are not in ascending order, or when any of the other settings is negative.
+func NewLadder(config LadderConfig, options *Options) (*Ladder, error) {
+	if len(config.Tiers) == 0 {
+		return nil, fmt.Errorf("%w: no tiers", ErrInvalidLadder)
+	}
+	if !(isFinite(config.Hysteresis) && config.Hysteresis >= 0) || config.PlacementMatches < 0 {
+		return nil, fmt.Errorf("%w: Hysteresis and PlacementMatches cannot be negative", ErrInvalidLadder)
+	}
+
+	if config.Scale == nil {
+		config.Scale = SkillRatingScale(options)
+	}
+
+	ladder := &Ladder{config: config, options: options}
+
+	for i, tier := range config.Tiers {
+		end := config.MaxValue
+		if i+1 < len(config.Tiers) {
+			end = config.Tiers[i+1].MinValue
+		}
+
+		if !(isFinite(tier.MinValue) && isFinite(end) && tier.MinValue < end) {
+			return nil, fmt.Errorf("%w: tier %q must start before %v", ErrInvalidLadder, tier.Name, end)
+		}
+		if tier.Divisions < 0 {
+			return nil, fmt.Errorf("%w: tier %q has a negative amount of divisions", ErrInvalidLadder, tier.Name)
+		}
+
+		divisions := tier.Divisions
+		if divisions == 0 {
+			divisions = 1
+		}
+
+		ladder.tierStarts = append(ladder.tierStarts, len(ladder.starts))
+		for division := 0; division < divisions; division++ {
+			ladder.starts = append(ladder.starts, tier.MinValue+float64(division)*(end-tier.MinValue)/float64(divisions))
+		}
+	}
+
+	return ladder, nil
+}
+
+// Standing returns the standing of a player, given their rating, how many games they played and
+// their previous standing, which is nil for players that were never placed. Players that finish
+// their placement matches are placed directly where their rating is. After that, they are promoted
+// as soon as they reach a higher division, but only demoted once they drop more than
+// LadderConfig.Hysteresis below the start of their division.
+func (l *Ladder) Standing(rating Rating, games int, previous *Standing) Standing {
+	value := DisplayRating(rating, l.config.Scale, l.options)
+
+	if games < l.config.PlacementMatches {
+		return Standing{PlacementsLeft: l.config.PlacementMatches - games, Value: value}
+	}
+
+	step := l.step(value)
+
+	if previous != nil && previous.Placed {
+		held := l.previousStep(previous)
+
+		if step < held {
+			step = held
+			if value < l.starts[held]-l.config.Hysteresis {
+				step = l.step(value + l.config.Hysteresis)
+			}
+		}
+	}
+
+	return l.standing(step, value)
+}
+
+// step returns the division a display value falls into, as an index on starts.
+func (l *Ladder) step(value float64) int {
+	step := sort.Search(len(l.starts), func(i int) bool {
+		return l.starts[i] > value
+	}) - 1
+
+	if step < 0 {
+		return 0
+	}
+
+	return step
+}
+
+// previousStep returns the index on starts of a standing, clamped to the ladder, in case the
+// standing comes from a ladder that had other tiers.
+func (l *Ladder) previousStep(standing *Standing) int {
+	tier := standing.Tier
+	if tier < 0 {
+		tier = 0
+	}
+	if tier >= len(l.tierStarts) {
+		tier = len(l.tierStarts) - 1
+	}
+
+	step := l.tierStarts[tier] + l.divisions(tier) - standing.Division
+	if step < l.tierStarts[tier] {
+		step = l.tierStarts[tier]
+	}
+	if step >= l.tierStarts[tier]+l.divisions(tier) {
+		step = l.tierStarts[tier] + l.divisions(tier) - 1
+	}
+
+	return step
+}
+
+func (l *Ladder) divisions(tier int) int {
+	if tier+1 < len(l.tierStarts) {
+		return l.tierStarts[tier+1] - l.tierStarts[tier]
+	}
+
+	return len(l.starts) - l.tierStarts[tier]
+}
+
+func (l *Ladder) standing(step int, value float64) Standing {
+	tier := sort.Search(len(l.tierStarts), func(i int) bool {
+		return l.tierStarts[i] > step
+	}) - 1
+
+	return Standing{
+		Placed:   true,
+		Tier:     tier,
+		Name:     l.config.Tiers[tier].Name,
+		Division: l.tierStarts[tier] + l.divisions(tier) - step,
+		Value:    value,
+	}
+}
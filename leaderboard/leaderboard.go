@@ -0,0 +1,246 @@
+// Package leaderboard keeps players ranked by a conservative estimate of their skill, the Ordinal
+// of the openskill package by default, and answers the usual questions of a ranking page: who is on
+// top, where a player stands and who is around them.
+//
+// The ranking is kept sorted as ratings change, so each update only moves the player it concerns,
+// by binary search, instead of sorting every player again.
+package leaderboard
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+// ErrInvalidConfig is returned when the configuration of a leaderboard is out of its valid range.
+var ErrInvalidConfig = errors.New("leaderboard: invalid config")
+
+// ScoreFunc computes the value players are ranked by. Higher values rank higher.
+type ScoreFunc func(rating openskill.Rating) float64
+
+// Config holds how players are scored and which of them are eligible to be ranked.
+type Config struct {
+	// Score is the value players are ranked by. When nil, the Ordinal of the rating is used.
+	Score ScoreFunc
+
+	// MinGames is the amount of games a player must have played to be ranked.
+	MinGames int
+
+	// MaxSigma is the largest uncertainty a player can have to be ranked, so players the system is
+	// not yet confident about stay off the leaderboard. When zero, there is no limit.
+	MaxSigma float64
+
+	// Options holds the constants used to compute the default score.
+	Options *openskill.Options
+}
+
+// Entry is a player tracked by the leaderboard.
+type Entry[ID comparable] struct {
+	ID     ID
+	Rating openskill.Rating
+	Games  int
+	Score  float64
+
+	// sequence breaks ties between equal scores, ranking the player that got the score first higher.
+	sequence uint64
+}
+
+// Leaderboard ranks players by their score. Players that are not eligible are tracked, but not
+// ranked. It is not safe for concurrent use.
+type Leaderboard[ID comparable] struct {
+	config   Config
+	players  map[ID]*Entry[ID]
+	ranked   []*Entry[ID]
+	sequence uint64
+}
+
+// New creates an empty leaderboard.
+func New[ID comparable](config Config) (*Leaderboard[ID], error) {
+	if config.MinGames < 0 {
+		return nil, fmt.Errorf("%w: MinGames cannot be negative", ErrInvalidConfig)
+	}
+	if !(config.MaxSigma >= 0 && !math.IsInf(config.MaxSigma, 0)) {
+		return nil, fmt.Errorf("%w: MaxSigma must be non-negative and finite", ErrInvalidConfig)
+	}
+
+	if config.Score == nil {
+		options := config.Options
+		config.Score = func(rating openskill.Rating) float64 {
+			return openskill.Ordinal(rating, options)
+		}
+	}
+
+	return &Leaderboard[ID]{config: config, players: make(map[ID]*Entry[ID])}, nil
+}
+
+// Set sets the rating of a player and how many games they played, adding them if needed.
+func (l *Leaderboard[ID]) Set(id ID, rating openskill.Rating, games int) {
+	entry, ok := l.players[id]
+	if ok {
+		l.unrank(entry)
+	} else {
+		entry = &Entry[ID]{ID: id}
+		l.players[id] = entry
+	}
+
+	score := l.config.Score(rating)
+	if !ok || score != entry.Score {
+		l.sequence++
+		entry.sequence = l.sequence
+	}
+
+	entry.Rating = rating
+	entry.Games = games
+	entry.Score = score
+
+	l.rank(entry)
+}
+
+// Record updates the leaderboard with the new ratings of the players of a game, such as the
+// result of openskill.RateRosters, counting one more game for each of them.
+func (l *Leaderboard[ID]) Record(ratings map[ID]openskill.Rating) {
+	for id, rating := range ratings {
+		games := 0
+		if entry, ok := l.players[id]; ok {
+			games = entry.Games
+		}
+
+		l.Set(id, rating, games+1)
+	}
+}
+
+// Remove removes a player from the leaderboard, returning whether they were on it.
+func (l *Leaderboard[ID]) Remove(id ID) bool {
+	entry, ok := l.players[id]
+	if !ok {
+		return false
+	}
+
+	l.unrank(entry)
+	delete(l.players, id)
+
+	return true
+}
+
+// Get returns a player tracked by the leaderboard, whether they are ranked or not.
+func (l *Leaderboard[ID]) Get(id ID) (Entry[ID], bool) {
+	entry, ok := l.players[id]
+	if !ok {
+		return Entry[ID]{}, false
+	}
+
+	return *entry, true
+}
+
+// Len returns how many players are ranked.
+func (l *Leaderboard[ID]) Len() int {
+	return len(l.ranked)
+}
+
+// Top returns the best n ranked players, from the best to the worst.
+func (l *Leaderboard[ID]) Top(n int) []Entry[ID] {
+	return l.entries(0, n)
+}
+
+// Rank returns the position of a player, starting at 1, and whether they are ranked. Players with
+// the same score share the same position.
+func (l *Leaderboard[ID]) Rank(id ID) (int, bool) {
+	entry, ok := l.players[id]
+	if !ok || !l.eligible(entry) {
+		return 0, false
+	}
+
+	return l.above(entry.Score) + 1, true
+}
+
+// Percentile returns the percentage, from 0 to 100, of the other ranked players that have a lower
+// score than the player, and whether the player is ranked. A player ranked alone is at 100.
+func (l *Leaderboard[ID]) Percentile(id ID) (float64, bool) {
+	entry, ok := l.players[id]
+	if !ok || !l.eligible(entry) {
+		return 0, false
+	}
+
+	if len(l.ranked) == 1 {
+		return 100, true
+	}
+
+	below := len(l.ranked) - sort.Search(len(l.ranked), func(i int) bool {
+		return l.ranked[i].Score < entry.Score
+	})
+
+	return 100 * float64(below) / float64(len(l.ranked)-1), true
+}
+
+// Neighbours returns up to n ranked players above and n below a player, along with the player,
+// from the best to the worst, and whether the player is ranked.
+func (l *Leaderboard[ID]) Neighbours(id ID, n int) ([]Entry[ID], bool) {
+	entry, ok := l.players[id]
+	if !ok || !l.eligible(entry) {
+		return nil, false
+	}
+
+	index := l.index(entry)
+
+	return l.entries(index-n, index+n+1), true
+}
+
+// entries copies the ranked players between two positions, clamped to the ranking.
+func (l *Leaderboard[ID]) entries(from, to int) []Entry[ID] {
+	if from < 0 {
+		from = 0
+	}
+	if to > len(l.ranked) {
+		to = len(l.ranked)
+	}
+
+	result := make([]Entry[ID], 0)
+	for i := from; i < to; i++ {
+		result = append(result, *l.ranked[i])
+	}
+
+	return result
+}
+
+func (l *Leaderboard[ID]) eligible(entry *Entry[ID]) bool {
+	return entry.Games >= l.config.MinGames && (l.config.MaxSigma == 0 || entry.Rating.SkillUncertaintyDegree <= l.config.MaxSigma)
+}
+
+// above returns how many ranked players have a higher score.
+func (l *Leaderboard[ID]) above(score float64) int {
+	return sort.Search(len(l.ranked), func(i int) bool {
+		return l.ranked[i].Score <= score
+	})
+}
+
+// index returns where a player is, or would be, on the ranking.
+func (l *Leaderboard[ID]) index(entry *Entry[ID]) int {
+	return sort.Search(len(l.ranked), func(i int) bool {
+		other := l.ranked[i]
+		return other.Score < entry.Score || (other.Score == entry.Score && other.sequence >= entry.sequence)
+	})
+}
+
+func (l *Leaderboard[ID]) rank(entry *Entry[ID]) {
+	if !l.eligible(entry) {
+		return
+	}
+
+	index := l.index(entry)
+
+	l.ranked = append(l.ranked, nil)
+	copy(l.ranked[index+1:], l.ranked[index:])
+	l.ranked[index] = entry
+}
+
+func (l *Leaderboard[ID]) unrank(entry *Entry[ID]) {
+	index := l.index(entry)
+	if index == len(l.ranked) || l.ranked[index] != entry {
+		return
+	}
+
+	l.ranked = append(l.ranked[:index], l.ranked[index+1:]...)
+}
@@ -0,0 +1,128 @@
+package leaderboard_test
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/eullerpereira94/openskill"
+	"github.com/eullerpereira94/openskill/leaderboard"
+)
+
+// withOrdinal returns a rating with the given ordinal under the default constants.
+func withOrdinal(ordinal float64) openskill.Rating {
+	return openskill.Rating{AveragePlayerSkill: ordinal + 3, SkillUncertaintyDegree: 1}
+}
+
+func ids(entries []leaderboard.Entry[string]) []string {
+	result := make([]string, len(entries))
+	for i, entry := range entries {
+		result[i] = entry.ID
+	}
+	return result
+}
+
+func TestLeaderboard(t *testing.T) {
+	board, err := leaderboard.New[string](leaderboard.Config{MinGames: 1, MaxSigma: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	board.Set("a", withOrdinal(10), 3)
+	board.Set("b", withOrdinal(30), 3)
+	board.Set("c", withOrdinal(20), 3)
+	board.Set("d", withOrdinal(20), 3)
+	board.Set("e", withOrdinal(40), 0)
+	board.Set("f", openskill.Rating{AveragePlayerSkill: 50, SkillUncertaintyDegree: 8}, 3)
+
+	if got := ids(board.Top(10)); !reflect.DeepEqual(got, []string{"b", "c", "d", "a"}) {
+		t.Fatalf("unexpected ranking %v", got)
+	}
+	if got := ids(board.Top(2)); !reflect.DeepEqual(got, []string{"b", "c"}) {
+		t.Fatalf("unexpected top 2 %v", got)
+	}
+
+	for id, expected := range map[string]int{"b": 1, "c": 2, "d": 2, "a": 4} {
+		if rank, ok := board.Rank(id); !ok || rank != expected {
+			t.Errorf("expected %s to be ranked %d, got %d", id, expected, rank)
+		}
+	}
+	for _, id := range []string{"e", "f", "g"} {
+		if _, ok := board.Rank(id); ok {
+			t.Errorf("expected %s not to be ranked", id)
+		}
+	}
+
+	if percentile, _ := board.Percentile("b"); percentile != 100 {
+		t.Errorf("expected b to be at the 100th percentile, got %v", percentile)
+	}
+	if percentile, _ := board.Percentile("c"); percentile != 100.0/3 {
+		t.Errorf("expected c to be at the 33rd percentile, got %v", percentile)
+	}
+	if percentile, _ := board.Percentile("a"); percentile != 0 {
+		t.Errorf("expected a to be at the 0th percentile, got %v", percentile)
+	}
+
+	if neighbours, _ := board.Neighbours("d", 1); !reflect.DeepEqual(ids(neighbours), []string{"c", "d", "a"}) {
+		t.Errorf("unexpected neighbours %v", ids(neighbours))
+	}
+	if neighbours, _ := board.Neighbours("b", 2); !reflect.DeepEqual(ids(neighbours), []string{"b", "c", "d"}) {
+		t.Errorf("unexpected neighbours %v", ids(neighbours))
+	}
+
+	// e becomes eligible after a game and a goes up.
+	board.Record(map[string]openskill.Rating{"e": withOrdinal(35), "a": withOrdinal(25)})
+
+	if got := ids(board.Top(10)); !reflect.DeepEqual(got, []string{"e", "b", "a", "c", "d"}) {
+		t.Fatalf("unexpected ranking %v", got)
+	}
+	if entry, _ := board.Get("a"); entry.Games != 4 {
+		t.Errorf("expected a to have 4 games, got %d", entry.Games)
+	}
+
+	if !board.Remove("b") || board.Remove("b") {
+		t.Errorf("expected b to be removed once")
+	}
+	if got := ids(board.Top(10)); !reflect.DeepEqual(got, []string{"e", "a", "c", "d"}) {
+		t.Fatalf("unexpected ranking %v", got)
+	}
+
+	if _, err := leaderboard.New[string](leaderboard.Config{MinGames: -1}); !errors.Is(err, leaderboard.ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+func TestLeaderboardIncremental(t *testing.T) {
+	board, err := leaderboard.New[int](leaderboard.Config{Score: func(rating openskill.Rating) float64 {
+		return rating.AveragePlayerSkill
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	skills := map[int]float64{}
+
+	for i := 0; i < 2000; i++ {
+		id := rng.Intn(100)
+		skills[id] = float64(rng.Intn(50))
+		board.Set(id, openskill.Rating{AveragePlayerSkill: skills[id], SkillUncertaintyDegree: 1}, 1)
+	}
+
+	top := board.Top(len(skills))
+	if len(top) != len(skills) {
+		t.Fatalf("expected %d players, got %d", len(skills), len(top))
+	}
+	if !sort.SliceIsSorted(top, func(i, j int) bool {
+		return top[i].Score > top[j].Score
+	}) {
+		t.Fatalf("expected the ranking to stay sorted")
+	}
+	for _, entry := range top {
+		if entry.Score != skills[entry.ID] {
+			t.Fatalf("expected %d to have score %v, got %v", entry.ID, skills[entry.ID], entry.Score)
+		}
+	}
+}
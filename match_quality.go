@@ -0,0 +1,69 @@
+package openskill
+
+import (
+	"math"
+
+	"github.com/samber/lo"
+	"gonum.org/v1/gonum/mat"
+)
+
+// MatchQuality returns how balanced a match between the teams is expected to be, on a scale from
+// 0 to 1, where 1 means the teams are certainly even. It is the match quality from TrueSkill: the
+// probability of a draw between the teams relative to the probability of a draw if every skill
+// was known exactly. It gets closer to 1 as the skills of the teams get closer to each other,
+// and lower as the uncertainty about them grows, so a matchmaker can threshold on it alone.
+// The skills are aggregated the same way as the rating models do, and every player adds
+// Options.VarianceForTeamPerformance to the variance of their team performance, scaled by their
+// weight. If there is only one team, the function will return 1, and if there is no teams, there
+// is no match to speak of, so it will return 0.
+func MatchQuality(teams []Team, options *Options) float64 {
+	m := len(teams)
+
+	if m == 0 {
+		return 0
+	}
+
+	if m == 1 {
+		return 1
+	}
+
+	betaSq := betaSq(options)
+	teamRatings := teamRatings(options)(teams)
+
+	// the performance of adjacent teams is compared, as every other comparison follows from them.
+	a := mat.NewDense(m-1, m, nil)
+	for k := 0; k < m-1; k++ {
+		a.Set(k, k, 1)
+		a.Set(k, k+1, -1)
+	}
+
+	mean := mat.NewVecDense(m, lo.Map(teamRatings, func(item *teamRating, index int) float64 {
+		return item.TeamMu
+	}))
+	performanceVariance := mat.NewDiagDense(m, lo.Map(teamRatings, func(item *teamRating, index int) float64 {
+		return betaSq * lo.Sum(lo.Map(item.Weights, func(weight float64, index int) float64 {
+			return weight * weight
+		}))
+	}))
+	skillVariance := mat.NewDiagDense(m, lo.Map(teamRatings, func(item *teamRating, index int) float64 {
+		return item.TeamSigmaSq
+	}))
+
+	var performance, total, variance mat.Dense
+	performance.Product(a, performanceVariance, a.T())
+	variance.Add(performanceVariance, skillVariance)
+	total.Product(a, &variance, a.T())
+
+	var difference mat.VecDense
+	difference.MulVec(a, mean)
+
+	var solved mat.VecDense
+	if err := solved.SolveVec(&total, &difference); err != nil {
+		return 0
+	}
+
+	exponent := -0.5 * mat.Dot(&difference, &solved)
+	ratio := mat.Det(&performance) / mat.Det(&total)
+
+	return math.Exp(exponent) * math.Sqrt(ratio)
+}
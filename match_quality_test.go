@@ -0,0 +1,44 @@
+package openskill_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+func TestMatchQuality(t *testing.T) {
+	newPlayer := func() *openskill.Rating {
+		return openskill.NewRating(nil, nil)
+	}
+
+	// The match quality of two new players, from the documentation of the trueskill Python package.
+	if quality := openskill.MatchQuality([]openskill.Team{{newPlayer()}, {newPlayer()}}, nil); !withinTolerance(math.Sqrt(0.2), quality, 1e-12) {
+		t.Errorf("Expected a quality of %f, got %f", math.Sqrt(0.2), quality)
+	}
+
+	even := openskill.MatchQuality([]openskill.Team{{newPlayer(), newPlayer()}, {newPlayer(), newPlayer()}, {newPlayer(), newPlayer()}}, nil)
+	uneven := openskill.MatchQuality([]openskill.Team{
+		{newPlayer(), newPlayer()},
+		{newPlayer(), newPlayer()},
+		{newPlayer(), openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: 40, SkillUncertaintyDegree: 25.0 / 3}, nil)},
+	}, nil)
+	if even <= 0 || even > 1 || uneven >= even {
+		t.Errorf("Expected an uneven match to have a lower quality, got %f and %f", uneven, even)
+	}
+
+	confident := openskill.MatchQuality([]openskill.Team{
+		{openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: 25, SkillUncertaintyDegree: 1}, nil)},
+		{openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: 25, SkillUncertaintyDegree: 1}, nil)},
+	}, nil)
+	if confident <= math.Sqrt(0.2) || confident > 1 {
+		t.Errorf("Expected a match between confident equal players to have a higher quality, got %f", confident)
+	}
+
+	if quality := openskill.MatchQuality([]openskill.Team{{newPlayer()}}, nil); quality != 1 {
+		t.Errorf("Expected a single team to have a quality of 1, got %f", quality)
+	}
+	if quality := openskill.MatchQuality(nil, nil); quality != 0 {
+		t.Errorf("Expected no teams to have a quality of 0, got %f", quality)
+	}
+}
@@ -0,0 +1,275 @@
+// Package matchmaking implements a queue that groups waiting players into lobbies of similar skill,
+// built on top of the ratings, ordinals and predictions of the openskill package.
+//
+// Players are compared by their Ordinal. Each player accepts opponents within a window around their
+// own ordinal, which widens the longer they wait, so nobody waits forever. The clock and the random
+// number generator are injected, which makes the matcher deterministic under test.
+package matchmaking
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/eullerpereira94/openskill"
+	"github.com/samber/lo"
+)
+
+var (
+	// ErrInvalidConfig is returned when the configuration of a matcher is out of its valid range.
+	ErrInvalidConfig = errors.New("matchmaking: invalid config")
+
+	// ErrAlreadyQueued is returned when a player that is already waiting is added to the queue again.
+	ErrAlreadyQueued = errors.New("matchmaking: player is already queued")
+)
+
+// Clock returns the current time. It is time.Now outside of tests.
+type Clock func() time.Time
+
+// Config holds the shape of the lobbies and how the skill windows of waiting players widen.
+type Config struct {
+	// TeamSizes is the shape of a lobby, such as []int{5, 5} for a 5v5 match.
+	TeamSizes []int
+
+	// InitialWindow is how far, in ordinal points, the ordinal of an opponent can be from the
+	// ordinal of a player that just joined the queue.
+	InitialWindow float64
+
+	// WindowGrowth is how much the window of a player widens for each second they wait.
+	WindowGrowth float64
+
+	// MaxWindow caps the window of a player. When zero, the window widens without a limit.
+	MaxWindow float64
+
+	// MinQuality is the lowest openskill.MatchQuality a lobby must have to be emitted.
+	MinQuality float64
+
+	// Objective is what openskill.BalanceTeams optimizes for when splitting a lobby into teams.
+	Objective openskill.BalanceObjective
+
+	// Options holds the constants used to compute ordinals, balance teams and predict the quality.
+	Options *openskill.Options
+}
+
+// Entry is a player waiting on the queue.
+type Entry[ID comparable] struct {
+	ID         ID
+	Rating     openskill.Rating
+	EnqueuedAt time.Time
+}
+
+// Match is a lobby formed by the matcher, with its teams balanced by openskill.BalanceTeams.
+type Match[ID comparable] struct {
+	// Teams holds the identifiers of the players of each team, following Config.TeamSizes.
+	Teams [][]ID
+
+	// Ratings holds the ratings of the players, with the same shape as Teams.
+	Ratings []openskill.Team
+
+	// Quality is the openskill.MatchQuality of the teams.
+	Quality float64
+
+	// Waited is the longest time a player of the lobby waited on the queue.
+	Waited time.Duration
+
+	// CreatedAt is when the lobby was formed.
+	CreatedAt time.Time
+}
+
+// Matcher is a queue of players that forms lobbies out of them. It is not safe for concurrent use.
+type Matcher[ID comparable] struct {
+	config Config
+	clock  Clock
+	rng    *rand.Rand
+	queue  []Entry[ID]
+}
+
+// New creates a matcher with an empty queue. When clock is nil, time.Now is used, and when rng is
+// nil, a generator with a fixed seed is used.
+func New[ID comparable](config Config, clock Clock, rng *rand.Rand) (*Matcher[ID], error) {
+	if len(config.TeamSizes) < 2 || lo.Min(config.TeamSizes) < 1 {
+		return nil, fmt.Errorf("%w: a lobby needs at least two teams with at least one player, got %v", ErrInvalidConfig, config.TeamSizes)
+	}
+	if config.InitialWindow < 0 || config.WindowGrowth < 0 || config.MaxWindow < 0 {
+		return nil, fmt.Errorf("%w: windows cannot be negative", ErrInvalidConfig)
+	}
+	if config.MinQuality < 0 || config.MinQuality > 1 {
+		return nil, fmt.Errorf("%w: MinQuality must be in the [0, 1] interval", ErrInvalidConfig)
+	}
+
+	if clock == nil {
+		clock = time.Now
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewSource(1))
+	}
+
+	return &Matcher[ID]{config: config, clock: clock, rng: rng}, nil
+}
+
+// Enqueue adds a player to the queue, at the current time.
+func (m *Matcher[ID]) Enqueue(id ID, rating openskill.Rating) error {
+	if lo.ContainsBy(m.queue, func(entry Entry[ID]) bool {
+		return entry.ID == id
+	}) {
+		return fmt.Errorf("%w: %v", ErrAlreadyQueued, id)
+	}
+
+	m.queue = append(m.queue, Entry[ID]{ID: id, Rating: rating, EnqueuedAt: m.clock()})
+
+	return nil
+}
+
+// Dequeue removes a player from the queue, returning whether they were waiting.
+func (m *Matcher[ID]) Dequeue(id ID) bool {
+	size := len(m.queue)
+
+	m.queue = lo.Reject(m.queue, func(entry Entry[ID], index int) bool {
+		return entry.ID == id
+	})
+
+	return len(m.queue) != size
+}
+
+// Queue returns the players waiting on the queue, in the order they joined it.
+func (m *Matcher[ID]) Queue() []Entry[ID] {
+	return append([]Entry[ID]{}, m.queue...)
+}
+
+// Window returns how far, in ordinal points, the ordinal of an opponent can currently be from
+// the ordinal of a waiting player.
+func (m *Matcher[ID]) Window(entry Entry[ID]) float64 {
+	return m.window(entry, m.clock())
+}
+
+// window returns the window of a waiting player at a given time.
+func (m *Matcher[ID]) window(entry Entry[ID], now time.Time) float64 {
+	window := m.config.InitialWindow + m.config.WindowGrowth*now.Sub(entry.EnqueuedAt).Seconds()
+
+	if m.config.MaxWindow > 0 {
+		return math.Min(window, m.config.MaxWindow)
+	}
+
+	return window
+}
+
+// Match forms as many lobbies as it can out of the players on the queue, removing them from it.
+// The players that waited the longest are matched first. Each of them is grouped with the closest
+// players by ordinal, with ties broken at random, as long as every two players of the lobby accept
+// each other according to their windows. The lobby is then split into balanced teams, and emitted
+// if its quality is at least Config.MinQuality.
+func (m *Matcher[ID]) Match() []Match[ID] {
+	now := m.clock()
+	lobbySize := lo.Sum(m.config.TeamSizes)
+
+	sort.SliceStable(m.queue, func(i, j int) bool {
+		return m.queue[i].EnqueuedAt.Before(m.queue[j].EnqueuedAt)
+	})
+
+	ordinals := lo.Map(m.queue, func(entry Entry[ID], index int) float64 {
+		return openskill.Ordinal(entry.Rating, m.config.Options)
+	})
+	windows := lo.Map(m.queue, func(entry Entry[ID], index int) float64 {
+		return m.window(entry, now)
+	})
+	accept := func(i, j int) bool {
+		distance := math.Abs(ordinals[i] - ordinals[j])
+		return distance <= windows[i] && distance <= windows[j]
+	}
+
+	used := make([]bool, len(m.queue))
+	var matches []Match[ID]
+
+	for anchor := range m.queue {
+		if used[anchor] {
+			continue
+		}
+
+		candidates := lo.Filter(lo.Range(len(m.queue)), func(candidate int, index int) bool {
+			return candidate != anchor && !used[candidate] && accept(anchor, candidate)
+		})
+
+		if len(candidates) < lobbySize-1 {
+			continue
+		}
+
+		m.rng.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return math.Abs(ordinals[candidates[i]]-ordinals[anchor]) < math.Abs(ordinals[candidates[j]]-ordinals[anchor])
+		})
+
+		lobby := []int{anchor}
+		for _, candidate := range candidates {
+			if len(lobby) == lobbySize {
+				break
+			}
+			if lo.EveryBy(lobby, func(member int) bool {
+				return accept(member, candidate)
+			}) {
+				lobby = append(lobby, candidate)
+			}
+		}
+
+		if len(lobby) < lobbySize {
+			continue
+		}
+
+		match, ok := m.lobbyMatch(lobby, now)
+		if !ok {
+			continue
+		}
+
+		for _, index := range lobby {
+			used[index] = true
+		}
+		matches = append(matches, match)
+	}
+
+	m.queue = lo.Filter(m.queue, func(entry Entry[ID], index int) bool {
+		return !used[index]
+	})
+
+	return matches
+}
+
+// lobbyMatch balances the players of a lobby into teams, telling whether the lobby is good enough.
+func (m *Matcher[ID]) lobbyMatch(lobby []int, now time.Time) (Match[ID], bool) {
+	ids := make(map[*openskill.Rating]ID)
+
+	players := lo.Map(lobby, func(index int, position int) *openskill.Rating {
+		rating := m.queue[index].Rating
+		ids[&rating] = m.queue[index].ID
+		return &rating
+	})
+
+	teams, err := openskill.BalanceTeams(players, m.config.TeamSizes, m.config.Objective, m.config.Options)
+	if err != nil {
+		return Match[ID]{}, false
+	}
+
+	quality := openskill.MatchQuality(teams, m.config.Options)
+	if quality < m.config.MinQuality {
+		return Match[ID]{}, false
+	}
+
+	waited := lo.Max(lo.Map(lobby, func(index int, position int) time.Duration {
+		return now.Sub(m.queue[index].EnqueuedAt)
+	}))
+
+	return Match[ID]{
+		Teams: lo.Map(teams, func(team openskill.Team, index int) []ID {
+			return lo.Map([]*openskill.Rating(team), func(rating *openskill.Rating, index int) ID {
+				return ids[rating]
+			})
+		}),
+		Ratings:   teams,
+		Quality:   quality,
+		Waited:    waited,
+		CreatedAt: now,
+	}, true
+}
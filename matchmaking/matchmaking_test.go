@@ -0,0 +1,173 @@
+package matchmaking_test
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/eullerpereira94/openskill"
+	"github.com/eullerpereira94/openskill/matchmaking"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// withOrdinal returns a rating with the given ordinal under the default constants.
+func withOrdinal(ordinal float64) openskill.Rating {
+	return openskill.Rating{AveragePlayerSkill: ordinal + 3, SkillUncertaintyDegree: 1}
+}
+
+func TestMatcher(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	matcher, err := matchmaking.New[string](matchmaking.Config{
+		TeamSizes:     []int{1, 1},
+		InitialWindow: 2,
+		WindowGrowth:  1,
+		MaxWindow:     15,
+	}, clock.Now, rand.New(rand.NewSource(7)))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for id, ordinal := range map[string]float64{"a": 10, "b": 11, "c": 30, "d": 31, "e": 50} {
+		if err := matcher.Enqueue(id, withOrdinal(ordinal)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if err := matcher.Enqueue("a", withOrdinal(10)); !errors.Is(err, matchmaking.ErrAlreadyQueued) {
+		t.Errorf("Expected %v, got %v", matchmaking.ErrAlreadyQueued, err)
+	}
+
+	matches := matcher.Match()
+	if len(matches) != 2 {
+		t.Fatalf("Expected two matches, got %v", matches)
+	}
+	pairs := map[string]string{}
+	for _, match := range matches {
+		pairs[match.Teams[0][0]] = match.Teams[1][0]
+		pairs[match.Teams[1][0]] = match.Teams[0][0]
+		if match.Quality <= 0 || match.Quality > 1 {
+			t.Errorf("Expected a quality between 0 and 1, got %f", match.Quality)
+		}
+	}
+	if pairs["a"] != "b" || pairs["c"] != "d" {
+		t.Errorf("Expected the closest players to be matched, got %v", pairs)
+	}
+
+	// e is too far from f until both waited long enough for their windows to widen.
+	clock.now = clock.now.Add(5 * time.Second)
+	if err := matcher.Enqueue("f", withOrdinal(60)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if matches := matcher.Match(); len(matches) != 0 {
+		t.Errorf("Expected no matches, got %v", matches)
+	}
+
+	clock.now = clock.now.Add(8 * time.Second)
+	matches = matcher.Match()
+	if len(matches) != 1 || matches[0].Waited != 13*time.Second {
+		t.Fatalf("Expected a match after the windows widened, got %v", matches)
+	}
+	if len(matcher.Queue()) != 0 {
+		t.Errorf("Expected the queue to be empty, got %v", matcher.Queue())
+	}
+
+	if _, err := matchmaking.New[string](matchmaking.Config{TeamSizes: []int{5}}, nil, nil); !errors.Is(err, matchmaking.ErrInvalidConfig) {
+		t.Errorf("Expected %v, got %v", matchmaking.ErrInvalidConfig, err)
+	}
+}
+
+func TestMatcherPairwiseWindows(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+	matcher, err := matchmaking.New[string](matchmaking.Config{TeamSizes: []int{1, 1, 1}, InitialWindow: 5}, clock.Now, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// b and c are both close enough to a, but too far from each other.
+	for _, player := range []struct {
+		id      string
+		ordinal float64
+	}{{"a", 10}, {"b", 5}, {"c", 15}} {
+		if err := matcher.Enqueue(player.id, withOrdinal(player.ordinal)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if matches := matcher.Match(); len(matches) != 0 {
+		t.Fatalf("Expected no matches, got %v", matches)
+	}
+
+	if err := matcher.Enqueue("d", withOrdinal(14)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	matches := matcher.Match()
+	if len(matches) != 1 {
+		t.Fatalf("Expected one match, got %v", matches)
+	}
+	if queue := matcher.Queue(); len(queue) != 1 || queue[0].ID != "b" {
+		t.Errorf("Expected b to be left waiting, got %v", queue)
+	}
+}
+
+func TestMatcherSingleNow(t *testing.T) {
+	// the clock moves forward every time it is read.
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time {
+		now = now.Add(10 * time.Second)
+		return now
+	}
+
+	matcher, err := matchmaking.New[string](matchmaking.Config{TeamSizes: []int{1, 1}, WindowGrowth: 1}, clock, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_ = matcher.Enqueue("a", withOrdinal(0))
+	_ = matcher.Enqueue("b", withOrdinal(15))
+
+	// at the time of the match, b waited 10 seconds, so their window is still too narrow.
+	if matches := matcher.Match(); len(matches) != 0 {
+		t.Errorf("Expected the windows to be computed at the time of the match, got %v", matches)
+	}
+}
+
+func TestMatcherDeterministic(t *testing.T) {
+	run := func() [][][]int {
+		clock := &fakeClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+		matcher, _ := matchmaking.New[int](matchmaking.Config{TeamSizes: []int{2, 2}, InitialWindow: 5}, clock.Now, rand.New(rand.NewSource(3)))
+
+		// many players with the same ordinal, so the random tie breaking matters.
+		for id := 0; id < 12; id++ {
+			_ = matcher.Enqueue(id, withOrdinal(20+float64(id%3)))
+		}
+
+		result := [][][]int{}
+		for _, match := range matcher.Match() {
+			result = append(result, match.Teams)
+		}
+		return result
+	}
+
+	first := run()
+	if len(first) != 3 {
+		t.Fatalf("Expected three matches, got %v", first)
+	}
+	if second := run(); !reflect.DeepEqual(first, second) {
+		t.Errorf("Expected the same matches with the same clock and seed, got %v and %v", first, second)
+	}
+
+	if removed := func() bool {
+		matcher, _ := matchmaking.New[int](matchmaking.Config{TeamSizes: []int{1, 1}}, nil, nil)
+		_ = matcher.Enqueue(1, withOrdinal(0))
+		return matcher.Dequeue(1) && !matcher.Dequeue(1)
+	}(); !removed {
+		t.Errorf("Expected a player to be dequeued once")
+	}
+}
@@ -0,0 +1,224 @@
+package openskill_test
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+// parityRating is a rating as written in the fixtures, using the names of the reference implementations.
+type parityRating struct {
+	Mu    float64 `json:"mu"`
+	Sigma float64 `json:"sigma"`
+}
+
+// parityFixture is a set of test vectors computed by one of the reference implementations.
+// Each file in testdata/parity holds one fixture. The fixtures of openskill.py and openskill.js
+// are written by the scripts in testdata/parity/generate, from the inputs in cases.json there,
+// and new vectors should be added to those inputs rather than to the fixtures.
+type parityFixture struct {
+	Source    string  `json:"source"`
+	Tolerance float64 `json:"tolerance"`
+	Rate      []struct {
+		Name       string           `json:"name"`
+		Model      string           `json:"model"`
+		Teams      [][]parityRating `json:"teams"`
+		Rankings   []int64          `json:"rankings"`
+		Scores     []int64          `json:"scores"`
+		Weights    [][]float64      `json:"weights"`
+		Tau        *float64         `json:"tau"`
+		LimitSigma *bool            `json:"limitSigma"`
+		Margin     *float64         `json:"margin"`
+		Expected   [][]parityRating `json:"expected"`
+	} `json:"rate"`
+	PredictWin []struct {
+		Name      string           `json:"name"`
+		Tolerance float64          `json:"tolerance"`
+		Teams     [][]parityRating `json:"teams"`
+		Expected  []float64        `json:"expected"`
+	} `json:"predictWin"`
+	PredictDraw []struct {
+		Name      string           `json:"name"`
+		Tolerance float64          `json:"tolerance"`
+		Teams     [][]parityRating `json:"teams"`
+		Expected  float64          `json:"expected"`
+	} `json:"predictDraw"`
+}
+
+var parityModels = map[string]openskill.Model{
+	"TrueSkill":              openskill.TrueSkill,
+	"PlackettLuce":           openskill.PlackettLuce,
+	"BradleyTerryFull":       openskill.BradleyTerryFull,
+	"BradleyTerryPart":       openskill.BradleyTerryPart,
+	"ThurstoneMostellerFull": openskill.ThurstoneMostellerFull,
+	"ThurstoneMostellerPart": openskill.ThurstoneMostellerPart,
+}
+
+func parityTeams(teams [][]parityRating) []openskill.Team {
+	result := []openskill.Team{}
+	for _, team := range teams {
+		ratings := openskill.Team{}
+		for _, rating := range team {
+			ratings = append(ratings, &openskill.Rating{AveragePlayerSkill: rating.Mu, SkillUncertaintyDegree: rating.Sigma})
+		}
+		result = append(result, ratings)
+	}
+	return result
+}
+
+func loadParityFixtures(t *testing.T) map[string]parityFixture {
+	paths, err := filepath.Glob(filepath.Join("testdata", "parity", "*.json"))
+	if err != nil {
+		t.Fatalf("Could not list the fixtures: %v", err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("Expected at least one fixture in testdata/parity")
+	}
+
+	fixtures := map[string]parityFixture{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Could not read %s: %v", path, err)
+		}
+
+		var fixture parityFixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			t.Fatalf("Could not parse %s: %v", path, err)
+		}
+		fixtures[filepath.Base(path)] = fixture
+	}
+
+	return fixtures
+}
+
+// TestParity checks the library against the vectors of the reference implementations.
+func TestParity(t *testing.T) {
+	for file, fixture := range loadParityFixtures(t) {
+		for _, c := range fixture.Rate {
+			model, ok := parityModels[c.Model]
+			if !ok {
+				t.Errorf("%s, %s: unknown model %s", file, c.Name, c.Model)
+				continue
+			}
+
+			result, err := openskill.RateE(parityTeams(c.Teams), openskill.Options{
+				Model:                      &model,
+				Rankings:                   c.Rankings,
+				Scores:                     c.Scores,
+				Weights:                    c.Weights,
+				Tau:                        c.Tau,
+				PreventUncertaintyIncrease: c.LimitSigma,
+				Margin:                     c.Margin,
+			})
+			if err != nil {
+				t.Errorf("%s, %s: expected no error, got %v", file, c.Name, err)
+				continue
+			}
+
+			for i, team := range c.Expected {
+				for j, expected := range team {
+					actual := result[i][j]
+					if !withinTolerance(expected.Mu, actual.AveragePlayerSkill, fixture.Tolerance) || !withinTolerance(expected.Sigma, actual.SkillUncertaintyDegree, fixture.Tolerance) {
+						t.Errorf("%s, %s: expected player %d/%d to be rated %v, got %v", file, c.Name, i, j, expected, *actual)
+					}
+				}
+			}
+		}
+
+		for _, c := range fixture.PredictWin {
+			result := openskill.PredictWin(parityTeams(c.Teams), nil)
+			for i, expected := range c.Expected {
+				if !withinTolerance(expected, result[i], c.Tolerance) {
+					t.Errorf("%s, %s: expected team %d to win with probability %v, got %v", file, c.Name, i, expected, result[i])
+				}
+			}
+		}
+
+		for _, c := range fixture.PredictDraw {
+			if result := openskill.PredictDraw(parityTeams(c.Teams), nil); !withinTolerance(c.Expected, result, c.Tolerance) {
+				t.Errorf("%s, %s: expected a draw probability of %v, got %v", file, c.Name, c.Expected, result)
+			}
+		}
+
+	}
+}
+
+// TestModelsSelfConsistency checks that the options of Rate relate to each other the way they are
+// documented, for every model. It compares the library with itself, so it is not a parity check,
+// which is what TestParity is for.
+func TestModelsSelfConsistency(t *testing.T) {
+	tau := 0.3
+
+	newTeams := func() []openskill.Team {
+		return parityTeams([][]parityRating{
+			{{Mu: 25, Sigma: 8.333333333333334}, {Mu: 29, Sigma: 3}},
+			{{Mu: 21, Sigma: 6}},
+			{{Mu: 27, Sigma: 2}, {Mu: 19, Sigma: 7}, {Mu: 25, Sigma: 8.333333333333334}},
+		})
+	}
+
+	for name, model := range parityModels {
+		model := model
+
+		// the models receive the teams ordered by their ranking.
+		teams := newTeams()
+		ranked := openskill.Rate(teams, openskill.Options{Model: &model, Rankings: []int64{3, 1, 2}})
+		direct := model([]openskill.Team{teams[1], teams[2], teams[0]}, nil)
+		if !withinRatings(snapshot(ranked), snapshot([]openskill.Team{direct[2], direct[0], direct[1]})) {
+			t.Errorf("%s: expected rankings to reorder the teams, got %v and %v", name, snapshot(ranked), snapshot(direct))
+		}
+
+		// higher scores are better placements.
+		scored := openskill.Rate(newTeams(), openskill.Options{Model: &model, Scores: []int64{1, 30, 20}})
+		if !withinRatings(snapshot(ranked), snapshot(scored)) {
+			t.Errorf("%s: expected scores to rank like rankings, got %v and %v", name, snapshot(ranked), snapshot(scored))
+		}
+
+		// tau is added to the uncertainty of every player before rating.
+		inflated := newTeams()
+		for _, team := range inflated {
+			for _, rating := range team {
+				rating.SkillUncertaintyDegree = math.Sqrt(rating.SkillUncertaintyDegree*rating.SkillUncertaintyDegree + tau*tau)
+			}
+		}
+		withTau := openskill.Rate(newTeams(), openskill.Options{Model: &model, Tau: &tau})
+		withoutTau := openskill.Rate(inflated, openskill.Options{Model: &model})
+		if !withinRatings(snapshot(withTau), snapshot(withoutTau)) {
+			t.Errorf("%s: expected tau to inflate the uncertainty, got %v and %v", name, snapshot(withTau), snapshot(withoutTau))
+		}
+
+		// a draw between equal teams does not change their skill, besides the tiny draw margin given by epsilon.
+		draw := openskill.Rate(parityTeams([][]parityRating{{{Mu: 25, Sigma: 8.333333333333334}}, {{Mu: 25, Sigma: 8.333333333333334}}}), openskill.Options{Model: &model, Rankings: []int64{1, 1}})
+		if !withinTolerance(25, draw[0][0].AveragePlayerSkill, 1e-5) || !withinTolerance(25, draw[1][0].AveragePlayerSkill, 1e-5) {
+			t.Errorf("%s: expected a draw between equal teams to keep their skill, got %v", name, snapshot(draw))
+		}
+
+		// a draw pulls unequal teams towards each other.
+		uneven := openskill.Rate(parityTeams([][]parityRating{{{Mu: 30, Sigma: 5}}, {{Mu: 20, Sigma: 5}}}), openskill.Options{Model: &model, Rankings: []int64{1, 1}})
+		if uneven[0][0].AveragePlayerSkill >= 30 || uneven[1][0].AveragePlayerSkill <= 20 {
+			t.Errorf("%s: expected a draw to pull the teams together, got %v", name, snapshot(uneven))
+		}
+	}
+}
+
+func withinRatings(a, b [][]openskill.Rating) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if !withinTolerance(a[i][j].AveragePlayerSkill, b[i][j].AveragePlayerSkill, 1e-12) || !withinTolerance(a[i][j].SkillUncertaintyDegree, b[i][j].SkillUncertaintyDegree, 1e-12) {
+				return false
+			}
+		}
+	}
+	return true
+}
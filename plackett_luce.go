@@ -1,10 +1,6 @@
 package openskill
 
-import (
-	"math"
-
-	"github.com/samber/lo"
-)
+import "math"
 
 // PlackettLuce represents the Plackett-Luce ranking model, which is the generalized version of the Bradley-Terry model
 func PlackettLuce(game []Team, options *Options) []Team {
@@ -14,34 +10,70 @@ func PlackettLuce(game []Team, options *Options) []Team {
 	sumQ := utilSumQ(teamRatings, c)
 	a := utilA(teamRatings)
 	gamma := gamma(options)
+	margin := marginFactor(options)
+	trace := newTracer(options, "PlackettLuce", teamRatings)
+
+	result := make([]Team, len(teamRatings))
+
+	// the ranks never decrease, so the teams ranked at the same place or above a team are the
+	// ones before end, and the teams it outranked are the ones from end on.
+	end := 0
+
+	for index, item := range teamRatings {
+		for end < len(teamRatings) && teamRatings[end].Rank <= item.Rank {
+			end++
+		}
 
-	return lo.Map(teamRatings, func(item *teamRating, index int) Team {
 		iMuOverCe := math.Exp(item.TeamMu / c)
+		iGamma := gamma(c, int64(len(teamRatings)), item.TeamMu, item.TeamSigmaSq, item.Team, item.Rank)
+
+		// When a team beats the ones ranked below it, the update is scaled by the average
+		// margin of victory over those teams.
+		outranked := 1.0
+		if end < len(teamRatings) {
+			var sum float64
+			for _, localItem := range teamRatings[end:] {
+				sum += margin(item, localItem)
+			}
+			outranked = sum / float64(len(teamRatings)-end)
+		}
 
-		filteredRatings := lo.Filter(teamRatings, func(localItem *teamRating, localIndex int) bool {
-			return localItem.Rank <= item.Rank
-		})
+		var omegaSum, deltaSum float64
 
-		_sums := lo.Reduce(filteredRatings, func(agg sums, item *teamRating, localIndex int) sums {
+		for localIndex, localItem := range teamRatings[:end] {
 			quotient := iMuOverCe / sumQ[localIndex]
 
-			agg.omegaSum = agg.omegaSum + lo.Ternary(index == localIndex, 1-quotient, -quotient)/float64(a[localIndex])
-			agg.deltaSum = agg.deltaSum + (quotient*(1-quotient))/float64(a[localIndex])
+			var factor, omega float64
+			if index == localIndex {
+				factor = outranked
+				omega = outranked * (1 - quotient) / float64(a[localIndex])
+			} else {
+				factor = margin(item, localItem)
+				omega = factor * -quotient / float64(a[localIndex])
+			}
+			delta := (quotient * (1 - quotient)) / float64(a[localIndex])
 
-			return agg
-		}, sums{omegaSum: 0, deltaSum: 0})
+			omegaSum = omegaSum + omega
+			deltaSum = deltaSum + delta
 
-		iGamma := gamma(c, int64(len(teamRatings)), item.TeamMu, item.TeamSigmaSq, item.Team, item.Rank)
-		iOmega := _sums.omegaSum * (item.TeamSigmaSq / c)
-		iDelta := iGamma * _sums.deltaSum * (item.TeamSigmaSq / math.Pow(c, 2))
+			trace.opponent(index, localItem, OpponentTrace{
+				C:      c,
+				Margin: factor,
+				Omega:  omega * (item.TeamSigmaSq / c),
+				Delta:  iGamma * delta * (item.TeamSigmaSq / math.Pow(c, 2)),
+			})
+		}
 
-		result := lo.Map([]*Rating(*item.Team), func(finalItem *Rating, index int) *Rating {
-			return &Rating{
-				AveragePlayerSkill:     finalItem.AveragePlayerSkill + (math.Pow(finalItem.SkillUncertaintyDegree, 2)/item.TeamSigmaSq)*iOmega,
-				SkillUncertaintyDegree: finalItem.SkillUncertaintyDegree * math.Sqrt(math.Max(1-(math.Pow(finalItem.SkillUncertaintyDegree, 2)/item.TeamSigmaSq)*iDelta, epsilon)),
-			}
-		})
+		iOmega := omegaSum * (item.TeamSigmaSq / c)
+		iDelta := iGamma * deltaSum * (item.TeamSigmaSq / math.Pow(c, 2))
+
+		if team := trace.team(index); team != nil {
+			team.C, team.SumQ, team.A, team.Gamma = c, sumQ[index], a[index], iGamma
+		}
+		trace.update(index, iOmega, iDelta)
+
+		result[index] = updateTeam(item, iOmega, iDelta, epsilon)
+	}
 
-		return Team(result)
-	})
+	return result
 }
@@ -0,0 +1,129 @@
+package openskill
+
+import "fmt"
+
+// Player pairs a Rating with a stable identifier, so the result of a rating or a prediction
+// can always be mapped back to the right player, whatever order the teams are processed in.
+type Player[ID comparable] struct {
+	ID     ID
+	Rating Rating
+}
+
+// Roster is a team of identified players.
+type Roster[ID comparable] []Player[ID]
+
+// NewRoster is a small utility function to create a Roster from many players.
+func NewRoster[ID comparable](players ...Player[ID]) Roster[ID] {
+	slc := make([]Player[ID], 0)
+
+	slc = append(slc, players...)
+
+	return Roster[ID](slc)
+}
+
+// rostersToTeams converts rosters to teams, making sure that a player is not present more than once.
+func rostersToTeams[ID comparable](rosters []Roster[ID]) ([]Team, error) {
+	seen := make(map[ID]struct{})
+
+	teams := make([]Team, len(rosters))
+
+	for i, roster := range rosters {
+		team := make(Team, len(roster))
+
+		for j, player := range roster {
+			if _, ok := seen[player.ID]; ok {
+				return nil, fmt.Errorf("%w: %v", ErrDuplicatePlayer, player.ID)
+			}
+			seen[player.ID] = struct{}{}
+
+			rating := player.Rating
+			team[j] = &rating
+		}
+
+		teams[i] = team
+	}
+
+	return teams, nil
+}
+
+// RateRosters rates a group of rosters just like RateE does, returning the new ratings keyed by
+// the identifier of each player. The teams, rankings, scores and weights in options follow the
+// order of rosters.
+func RateRosters[ID comparable](rosters []Roster[ID], options Options) (map[ID]Rating, error) {
+	teams, err := rostersToTeams(rosters)
+	if err != nil {
+		return nil, err
+	}
+
+	rated, err := RateE(teams, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[ID]Rating)
+
+	for i, roster := range rosters {
+		for j, player := range roster {
+			result[player.ID] = *rated[i][j]
+		}
+	}
+
+	return result, nil
+}
+
+// PredictWinRosters returns, for each player, the probability that their team wins, as
+// computed by PredictWin.
+func PredictWinRosters[ID comparable](rosters []Roster[ID], options *Options) (map[ID]float64, error) {
+	teams, err := rostersToTeams(rosters)
+	if err != nil {
+		return nil, err
+	}
+
+	probabilities := PredictWin(teams, options)
+
+	result := make(map[ID]float64)
+
+	for i, roster := range rosters {
+		for _, player := range roster {
+			// PredictWin has no prediction for a lone team, which is certain to win.
+			if probabilities == nil {
+				result[player.ID] = 1
+				continue
+			}
+			result[player.ID] = probabilities[i]
+		}
+	}
+
+	return result, nil
+}
+
+// PredictDrawRosters returns the probability that the rosters tie, as computed by PredictDraw.
+func PredictDrawRosters[ID comparable](rosters []Roster[ID], options *Options) (float64, error) {
+	teams, err := rostersToTeams(rosters)
+	if err != nil {
+		return 0, err
+	}
+
+	return PredictDraw(teams, options), nil
+}
+
+// PredictRankRosters returns, for each player, the predicted rank and probability of their team,
+// as computed by PredictRank.
+func PredictRankRosters[ID comparable](rosters []Roster[ID], options *Options) (map[ID][]float64, error) {
+	teams, err := rostersToTeams(rosters)
+	if err != nil {
+		return nil, err
+	}
+
+	predictions := PredictRank(teams, options)
+
+	result := make(map[ID][]float64)
+
+	for i, roster := range rosters {
+		for _, player := range roster {
+			result[player.ID] = predictions[i]
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,99 @@
+package openskill_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+func TestRateRosters(t *testing.T) {
+	alice := openskill.Player[string]{ID: "alice", Rating: openskill.Rating{AveragePlayerSkill: 30, SkillUncertaintyDegree: 5}}
+	bob := openskill.Player[string]{ID: "bob", Rating: openskill.Rating{AveragePlayerSkill: 20, SkillUncertaintyDegree: 8}}
+	carol := openskill.Player[string]{ID: "carol", Rating: openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: 3}}
+
+	rosters := []openskill.Roster[string]{
+		openskill.NewRoster(alice),
+		openskill.NewRoster(bob, carol),
+	}
+
+	// Bob and Carol win, the teams get reordered internally before being rated.
+	result, err := openskill.RateRosters(rosters, openskill.Options{Rankings: []int64{2, 1}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := openskill.Rate([]openskill.Team{
+		openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 30, SkillUncertaintyDegree: 5}),
+		openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 20, SkillUncertaintyDegree: 8}, &openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: 3}),
+	}, openskill.Options{Rankings: []int64{2, 1}})
+
+	for id, rating := range map[string]openskill.Rating{"alice": *expected[0][0], "bob": *expected[1][0], "carol": *expected[1][1]} {
+		if result[id] != rating {
+			t.Errorf("Expected %s to be rated %v, got %v", id, rating, result[id])
+		}
+	}
+
+	if rosters[0][0].Rating != alice.Rating {
+		t.Errorf("Expected the rosters to be untouched, got %v", rosters[0][0].Rating)
+	}
+
+	_, err = openskill.RateRosters([]openskill.Roster[string]{openskill.NewRoster(alice), openskill.NewRoster(alice)}, openskill.Options{})
+	if !errors.Is(err, openskill.ErrDuplicatePlayer) {
+		t.Errorf("Expected %v, got %v", openskill.ErrDuplicatePlayer, err)
+	}
+
+	win, err := openskill.PredictWinRosters(rosters, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if win["bob"] != win["carol"] || !withinTolerance(win["alice"]+win["bob"], 1, 1e-9) {
+		t.Errorf("Expected teammates to share the team win probability, got %v", win)
+	}
+}
+
+func TestPredictRosters(t *testing.T) {
+	alice := openskill.Player[string]{ID: "alice", Rating: openskill.Rating{AveragePlayerSkill: 30, SkillUncertaintyDegree: 5}}
+	bob := openskill.Player[string]{ID: "bob", Rating: openskill.Rating{AveragePlayerSkill: 20, SkillUncertaintyDegree: 8}}
+	carol := openskill.Player[string]{ID: "carol", Rating: openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: 3}}
+	dave := openskill.Player[string]{ID: "dave", Rating: openskill.Rating{AveragePlayerSkill: 27, SkillUncertaintyDegree: 6}}
+
+	rosters := []openskill.Roster[string]{
+		openskill.NewRoster(alice),
+		openskill.NewRoster(bob, carol),
+		openskill.NewRoster(dave),
+	}
+	teams := []openskill.Team{
+		openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 30, SkillUncertaintyDegree: 5}),
+		openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 20, SkillUncertaintyDegree: 8}, &openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: 3}),
+		openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 27, SkillUncertaintyDegree: 6}),
+	}
+
+	draw, err := openskill.PredictDrawRosters(rosters, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expected := openskill.PredictDraw(teams, nil); draw != expected {
+		t.Errorf("Expected a draw probability of %f, got %f", expected, draw)
+	}
+
+	rank, err := openskill.PredictRankRosters(rosters, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := openskill.PredictRank(teams, nil)
+	for id, prediction := range map[string][]float64{"alice": expected[0], "bob": expected[1], "carol": expected[1], "dave": expected[2]} {
+		if !reflect.DeepEqual(rank[id], prediction) {
+			t.Errorf("Expected %s to be predicted %v, got %v", id, prediction, rank[id])
+		}
+	}
+
+	duplicated := []openskill.Roster[string]{openskill.NewRoster(alice), openskill.NewRoster(alice)}
+	if _, err := openskill.PredictDrawRosters(duplicated, nil); !errors.Is(err, openskill.ErrDuplicatePlayer) {
+		t.Errorf("Expected %v, got %v", openskill.ErrDuplicatePlayer, err)
+	}
+	if _, err := openskill.PredictRankRosters(duplicated, nil); !errors.Is(err, openskill.ErrDuplicatePlayer) {
+		t.Errorf("Expected %v, got %v", openskill.ErrDuplicatePlayer, err)
+	}
+}
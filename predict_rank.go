@@ -63,12 +63,11 @@ func PredictRank(teams []Team, options *Options) (predictions [][]float64) {
 	var totalPlayerCount float64
 	var teamIDs []int
 	teamMap := make(map[int]Team)
-	winProbs := make(map[int]float64)
+	winProbs := make([]float64, len(teams))
 	for i, t := range teams {
 		totalPlayerCount += float64(len(t))
 		teamIDs = append(teamIDs, i)
 		teamMap[i] = t
-		winProbs[i] = 0
 	}
 	denom := (n * (n - 1)) / 2
 	drawProbability := 1 / n
@@ -76,7 +75,7 @@ func PredictRank(teams []Team, options *Options) (predictions [][]float64) {
 	betaSq := betaSq(options)
 
 	for matchup := range itertools.PermutationsInt(teamIDs, 2) {
-		currentRatings := teamRatings(options)([]Team{teamMap[matchup[0]], teamMap[matchup[1]]})
+		currentRatings := teamRatings(subgameOptions(options, matchup))([]Team{teamMap[matchup[0]], teamMap[matchup[1]]})
 
 		muA := currentRatings[0].TeamMu
 		sigmaA := currentRatings[0].TeamSigmaSq
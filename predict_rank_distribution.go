@@ -0,0 +1,138 @@
+package openskill
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// exactRankDistributionLimit is the largest amount of teams for which PredictRankDistribution
+// computes the exact distribution. The exact computation grows with 2^n.
+const exactRankDistributionLimit = 12
+
+// rankDistributionSamples is the amount of simulated matches used by PredictRankDistribution
+// when there are too many teams for the exact computation.
+const rankDistributionSamples = 20000
+
+// rankDistributionSeed seeds the simulated matches, so the estimated distribution of a set of
+// teams is always the same.
+const rankDistributionSeed = 1
+
+// PredictRankDistribution returns the probability of each team finishing in each position, where
+// predictions[i][k] is the probability of the team i finishing in the position k, starting at zero
+// for the first place. Each row and each column of the result sums up to 1.
+//
+// The finishing order follows the Plackett-Luce model, on which each team strength is
+// exp(TeamMu / c), with the team skill and c aggregated the same way as the rating models do.
+// Up to 12 teams the distribution is exact, while for larger fields it is estimated with 20000
+// simulated matches from a fixed seed, so the result is deterministic. If there are no teams,
+// the function will return nil.
+func PredictRankDistribution(teams []Team, options *Options) [][]float64 {
+	if len(teams) == 0 {
+		return nil
+	}
+
+	teamRatings := teamRatings(options)(teams)
+	c := utilC(options)(teamRatings)
+
+	// the strengths are normalized by the strongest team, to avoid overflowing exp.
+	maxMu := lo.Max(lo.Map(teamRatings, func(item *teamRating, index int) float64 {
+		return item.TeamMu
+	}))
+	logStrengths := lo.Map(teamRatings, func(item *teamRating, index int) float64 {
+		return (item.TeamMu - maxMu) / c
+	})
+
+	if len(teams) <= exactRankDistributionLimit {
+		return exactRankDistribution(logStrengths)
+	}
+
+	return sampledRankDistribution(logStrengths, rankDistributionSamples, rand.New(rand.NewSource(rankDistributionSeed)))
+}
+
+// exactRankDistribution computes the rank distribution by going through every set of teams that
+// can take the first places, which is much cheaper than going through every possible order.
+func exactRankDistribution(logStrengths []float64) [][]float64 {
+	n := len(logStrengths)
+
+	strengths := lo.Map(logStrengths, func(item float64, index int) float64 {
+		return math.Exp(item)
+	})
+	total := lo.Sum(strengths)
+
+	predictions := make([][]float64, n)
+	for i := range predictions {
+		predictions[i] = make([]float64, n)
+	}
+
+	// placed[set] is the probability of the teams in set taking the first places, in any order,
+	// and remaining[set] is the strength of the teams that were not placed yet.
+	placed := make([]float64, 1<<n)
+	remaining := make([]float64, 1<<n)
+	placed[0] = 1
+	remaining[0] = total
+
+	for set := 0; set < 1<<n; set++ {
+		if placed[set] == 0 {
+			continue
+		}
+
+		position := bits.OnesCount(uint(set))
+
+		for i := 0; i < n; i++ {
+			if set&(1<<i) != 0 {
+				continue
+			}
+
+			probability := placed[set] * strengths[i] / remaining[set]
+			predictions[i][position] += probability
+
+			next := set | 1<<i
+			placed[next] += probability
+			remaining[next] = remaining[set] - strengths[i]
+		}
+	}
+
+	return predictions
+}
+
+// sampledRankDistribution estimates the rank distribution by simulating matches. Adding a Gumbel
+// distributed noise to the log of the strengths and sorting the teams by it yields orders that
+// follow the Plackett-Luce model.
+func sampledRankDistribution(logStrengths []float64, samples int, rng *rand.Rand) [][]float64 {
+	n := len(logStrengths)
+
+	predictions := make([][]float64, n)
+	for i := range predictions {
+		predictions[i] = make([]float64, n)
+	}
+
+	performances := make([]float64, n)
+	order := make([]int, n)
+
+	for sample := 0; sample < samples; sample++ {
+		for i := range performances {
+			performances[i] = logStrengths[i] - math.Log(rng.ExpFloat64())
+			order[i] = i
+		}
+
+		sort.Slice(order, func(i, j int) bool {
+			return performances[order[i]] > performances[order[j]]
+		})
+
+		for position, team := range order {
+			predictions[team][position]++
+		}
+	}
+
+	for i := range predictions {
+		for k := range predictions[i] {
+			predictions[i][k] /= float64(samples)
+		}
+	}
+
+	return predictions
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/eullerpereira94/openskill"
+	"github.com/samber/lo"
 )
 
 func TestRankDataMin(t *testing.T) {
@@ -94,3 +95,63 @@ func TestPredictRank(t *testing.T) {
 		t.Errorf("RankData failed for test case 5. Expected %v, but got %v", expectedoneTeam, oneTeam)
 	}
 }
+
+func TestPredictRankDistribution(t *testing.T) {
+	newTeams := func(amount int) []openskill.Team {
+		teams := []openskill.Team{}
+		for i := 0; i < amount; i++ {
+			teams = append(teams, openskill.NewTeam(openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: 20 + float64(i), SkillUncertaintyDegree: 3}, nil)))
+		}
+		return teams
+	}
+
+	for _, amount := range []int{1, 2, 5, 14} {
+		predictions := openskill.PredictRankDistribution(newTeams(amount), nil)
+
+		for i := 0; i < amount; i++ {
+			var row, column float64
+			for k := 0; k < amount; k++ {
+				row += predictions[i][k]
+				column += predictions[k][i]
+			}
+			if !withinTolerance(1, row, 1e-9) || !withinTolerance(1, column, 1e-9) {
+				t.Errorf("Expected the probabilities of %d teams to sum up to 1, got %f and %f", amount, row, column)
+			}
+		}
+
+		// the strongest team is the most likely to finish first.
+		for i := 0; i < amount-1; i++ {
+			if predictions[i][0] >= predictions[amount-1][0] {
+				t.Errorf("Expected team %d of %d to be less likely to win than the strongest team, got %v", i, amount, predictions[i][0])
+			}
+		}
+	}
+
+	// with two teams, the distribution is the Plackett-Luce win probability.
+	teams := newTeams(2)
+	predictions := openskill.PredictRankDistribution(teams, nil)
+	c := math.Sqrt(2*3*3 + 2*math.Pow(25.0/6, 2))
+	expected := math.Exp(21/c) / (math.Exp(20/c) + math.Exp(21/c))
+	if !withinTolerance(expected, predictions[1][0], 1e-12) || !withinTolerance(expected, predictions[0][1], 1e-12) {
+		t.Errorf("Expected the stronger team to win with probability %f, got %v", expected, predictions)
+	}
+}
+
+func TestPredictRankWeights(t *testing.T) {
+	newTeams := func(scale ...float64) []openskill.Team {
+		return lo.Map(scale, func(weight float64, index int) openskill.Team {
+			return openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: weight * (20 + float64(index)), SkillUncertaintyDegree: weight * 4})
+		})
+	}
+
+	// a weight scales the skill and the uncertainty of a player, so weighted teams are predicted
+	// like teams of players with their ratings scaled by the same weights.
+	weighted := openskill.PredictRank(newTeams(1, 1, 1, 1), &openskill.Options{Weights: [][]float64{{1}, {0.5}, {1}, {0.2}}})
+	scaled := openskill.PredictRank(newTeams(1, 0.5, 1, 0.2), nil)
+
+	for i := range scaled {
+		if weighted[i][0] != scaled[i][0] || !withinTolerance(scaled[i][1], weighted[i][1], 1e-12) {
+			t.Errorf("Expected team %d to be predicted %v, got %v", i, scaled[i], weighted[i])
+		}
+	}
+}
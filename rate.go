@@ -2,12 +2,33 @@ package openskill
 
 import (
 	"math"
-	"sort"
 
 	"github.com/samber/lo"
 )
 
-// Rate rates a group of teams with the provided optional parameters for classification
+// RateE rates a group of teams just like Rate does, but it first validates the teams and
+// the options provided. Malformed input, such as rankings or scores that do not match the
+// amount of teams, empty teams, nil ratings or invalid uncertainty values, is rejected with
+// one of the package Err* values instead of being rated. The error can be inspected with
+// errors.Is.
+func RateE(teams []Team, options Options) ([]Team, error) {
+	if err := validate(teams, &options); err != nil {
+		return nil, err
+	}
+
+	model := Model(PlackettLuce)
+	if options.Model != nil {
+		model = *options.Model
+	}
+	if err := validateMargin(&options, model); err != nil {
+		return nil, err
+	}
+
+	return Rate(teams, options), nil
+}
+
+// Rate rates a group of teams with the provided optional parameters for classification.
+// It does not validate its input, use RateE when the teams or options come from an untrusted source.
 func Rate(teams []Team, options Options) []Team {
 	var model Model
 	var processedTeams = make([]Team, len(teams))
@@ -23,41 +44,43 @@ func Rate(teams []Team, options Options) []Team {
 
 		processedTeams = lo.Map(teams, func(item Team, index int) Team {
 			return lo.Map([]*Rating(item), func(item *Rating, index int) *Rating {
-				item.SkillUncertaintyDegree = math.Sqrt(math.Pow(item.SkillUncertaintyDegree, 2) + tauSquared)
-				return item
+				rating := *item
+				rating.SkillUncertaintyDegree = math.Sqrt(math.Pow(item.SkillUncertaintyDegree, 2) + tauSquared)
+				return &rating
 			})
 		})
 	} else {
-		copy(processedTeams, teams)
-	}
-
-	var rank []int64
-
-	if len(options.Rankings) > 0 {
-		rank = lo.Map(options.Rankings, func(item int64, index int) int64 {
-			return item
-		})
-	} else if len(options.Scores) > 0 {
-		rank = lo.Map(options.Scores, func(item int64, index int) int64 {
-			return -item
+		processedTeams = lo.Map(teams, func(item Team, index int) Team {
+			return copyTeam(item)
 		})
-	} else {
-		rank = lo.RangeFrom[int64](1, len(teams))
 	}
 
+	rank := outcomeOrder(&options, len(teams))
+
 	orderedTeams, tenet := unwind(rank, processedTeams)
 
-	_newRanks := make([]int64, len(rank))
-	copy(_newRanks, rank)
+	options.Rankings = denseRanks(permute(rank, tenet), tieTolerance(&options))
+	options.FloatRankings = nil
 
-	sort.Slice(_newRanks, func(i, j int) bool {
-		return _newRanks[i] < _newRanks[j]
-	})
+	if scores := teamScores(&options); len(scores) > 0 {
+		options.FloatScores = permute(padded(scores, len(teams)), tenet)
+		options.Scores = nil
+	}
+
+	if len(options.Weights) > 0 {
+		options.Weights = permute(padded(options.Weights, len(teams)), tenet)
+	}
 
-	options.Rankings = _newRanks
+	if options.Trace != nil {
+		*options.Trace = Trace{}
+	}
 
 	newRatings := model(orderedTeams, &options)
 
+	if options.Trace != nil {
+		options.Trace.reorder(tenet)
+	}
+
 	reorderedTeams, _ := unwind(tenet, newRatings)
 
 	if options.Tau != nil && options.PreventUncertaintyIncrease != nil && *options.PreventUncertaintyIncrease {
@@ -71,3 +94,30 @@ func Rate(teams []Team, options Options) []Team {
 
 	return reorderedTeams
 }
+
+// outcomeOrder returns the values used to sort the teams before rating them, where lower values
+// mean better placements. Fractional rankings take precedence over integer rankings, which take
+// precedence over scores. Without any of them, the teams are ranked in the order they were provided.
+func outcomeOrder(options *Options, size int) []float64 {
+	var values []float64
+
+	switch {
+	case len(options.FloatRankings) > 0:
+		values = options.FloatRankings
+	case len(options.Rankings) > 0:
+		values = lo.Map(options.Rankings, func(item int64, index int) float64 {
+			return float64(item)
+		})
+	default:
+		values = lo.Map(teamScores(options), func(item float64, index int) float64 {
+			return -item
+		})
+	}
+
+	return lo.Map(make([]float64, size), func(item float64, index int) float64 {
+		if index < len(values) {
+			return values[index]
+		}
+		return float64(index + 1)
+	})
+}
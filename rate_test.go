@@ -0,0 +1,389 @@
+package openskill_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+func TestRateEValidation(t *testing.T) {
+	negative := -1.0
+	var nilModel openskill.Model
+
+	newTeams := func() []openskill.Team {
+		return []openskill.Team{
+			openskill.NewTeam(openskill.NewRating(nil, nil)),
+			openskill.NewTeam(openskill.NewRating(nil, nil)),
+		}
+	}
+
+	cases := []struct {
+		name    string
+		teams   []openskill.Team
+		options openskill.Options
+		err     error
+	}{
+		{"no teams", []openskill.Team{}, openskill.Options{}, openskill.ErrNoTeams},
+		{"empty team", []openskill.Team{openskill.NewTeam(openskill.NewRating(nil, nil)), {}}, openskill.Options{}, openskill.ErrEmptyTeam},
+		{"nil rating", []openskill.Team{openskill.NewTeam(openskill.NewRating(nil, nil)), {nil}}, openskill.Options{}, openskill.ErrNilRating},
+		{"nan mu", []openskill.Team{openskill.NewTeam(openskill.NewRating(nil, nil)), openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: math.NaN(), SkillUncertaintyDegree: 1})}, openskill.Options{}, openskill.ErrInvalidMu},
+		{"nan sigma", []openskill.Team{openskill.NewTeam(openskill.NewRating(nil, nil)), openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: math.NaN()})}, openskill.Options{}, openskill.ErrInvalidSigma},
+		{"negative sigma", []openskill.Team{openskill.NewTeam(openskill.NewRating(nil, nil)), openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: -1})}, openskill.Options{}, openskill.ErrInvalidSigma},
+		{"short rankings", newTeams(), openskill.Options{Rankings: []int64{1}}, openskill.ErrRankingsLength},
+		{"long scores", newTeams(), openskill.Options{Scores: []int64{1, 2, 3}}, openskill.ErrScoresLength},
+		{"negative tau", newTeams(), openskill.Options{Tau: &negative}, openskill.ErrInvalidOption},
+		{"nil model", newTeams(), openskill.Options{Model: &nilModel}, openskill.ErrNilModel},
+	}
+
+	for _, c := range cases {
+		result, err := openskill.RateE(c.teams, c.options)
+		if !errors.Is(err, c.err) {
+			t.Errorf("%s: expected error %v, got %v", c.name, c.err, err)
+		}
+		if result != nil {
+			t.Errorf("%s: expected no result, got %v", c.name, result)
+		}
+	}
+
+	result, err := openskill.RateE(newTeams(), openskill.Options{Rankings: []int64{2, 1}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result) != 2 || result[1][0].AveragePlayerSkill <= result[0][0].AveragePlayerSkill {
+		t.Errorf("Expected the second team to win, got %v and %v", *result[0][0], *result[1][0])
+	}
+}
+
+func snapshot(teams []openskill.Team) [][]openskill.Rating {
+	result := [][]openskill.Rating{}
+	for _, team := range teams {
+		ratings := []openskill.Rating{}
+		for _, rating := range team {
+			ratings = append(ratings, *rating)
+		}
+		result = append(result, ratings)
+	}
+	return result
+}
+
+func TestRateDoesNotMutateInput(t *testing.T) {
+	tau := 0.3
+	preventUncertaintyIncrease := true
+
+	optionsSet := map[string]openskill.Options{
+		"default":                      {},
+		"tau":                          {Tau: &tau},
+		"tau and prevent increase":     {Tau: &tau, PreventUncertaintyIncrease: &preventUncertaintyIncrease},
+		"tau with rankings and scores": {Tau: &tau, Scores: []int64{1, 5, 3}},
+	}
+
+	for name, options := range optionsSet {
+		teams := []openskill.Team{
+			openskill.NewTeam(openskill.NewRating(nil, nil), openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: 30, SkillUncertaintyDegree: 2}, nil)),
+			openskill.NewTeam(openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: 20, SkillUncertaintyDegree: 6}, nil)),
+			openskill.NewTeam(openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: 27, SkillUncertaintyDegree: 0.5}, nil)),
+		}
+		before := snapshot(teams)
+
+		result := openskill.Rate(teams, options)
+
+		after := snapshot(teams)
+		for i := range before {
+			for j := range before[i] {
+				if before[i][j] != after[i][j] {
+					t.Errorf("%s: input rating %d/%d changed from %v to %v", name, i, j, before[i][j], after[i][j])
+				}
+				if result[i][j] == teams[i][j] {
+					t.Errorf("%s: result rating %d/%d aliases the input rating", name, i, j)
+				}
+			}
+		}
+	}
+}
+
+func TestRatePreventUncertaintyIncrease(t *testing.T) {
+	tau := 0.3
+	preventUncertaintyIncrease := true
+
+	// A very confident player whose uncertainty would grow with tau after a single game.
+	newTeams := func() []openskill.Team {
+		return []openskill.Team{
+			openskill.NewTeam(openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: 25, SkillUncertaintyDegree: 0.1}, nil)),
+			openskill.NewTeam(openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: 25, SkillUncertaintyDegree: 0.1}, nil)),
+		}
+	}
+
+	teams := newTeams()
+	unclamped := openskill.Rate(teams, openskill.Options{Tau: &tau})
+	for i, team := range unclamped {
+		if team[0].SkillUncertaintyDegree <= teams[i][0].SkillUncertaintyDegree {
+			t.Fatalf("Expected tau to increase the uncertainty of team %d, got %f", i, team[0].SkillUncertaintyDegree)
+		}
+	}
+
+	teams = newTeams()
+	clamped := openskill.Rate(teams, openskill.Options{Tau: &tau, PreventUncertaintyIncrease: &preventUncertaintyIncrease})
+	for i, team := range clamped {
+		if team[0].SkillUncertaintyDegree != teams[i][0].SkillUncertaintyDegree {
+			t.Errorf("Expected the uncertainty of team %d to be clamped to %f, got %f", i, teams[i][0].SkillUncertaintyDegree, team[0].SkillUncertaintyDegree)
+		}
+		if team[0].AveragePlayerSkill != unclamped[i][0].AveragePlayerSkill {
+			t.Errorf("Expected the skill of team %d to be unaffected by the clamp, got %f and %f", i, team[0].AveragePlayerSkill, unclamped[i][0].AveragePlayerSkill)
+		}
+	}
+}
+
+func TestRateWeights(t *testing.T) {
+	models := map[string]openskill.Model{
+		"PlackettLuce":           openskill.PlackettLuce,
+		"BradleyTerryFull":       openskill.BradleyTerryFull,
+		"BradleyTerryPart":       openskill.BradleyTerryPart,
+		"ThurstoneMostellerFull": openskill.ThurstoneMostellerFull,
+		"ThurstoneMostellerPart": openskill.ThurstoneMostellerPart,
+	}
+
+	newTeams := func() []openskill.Team {
+		return []openskill.Team{
+			openskill.NewTeam(openskill.NewRating(nil, nil), openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: 28, SkillUncertaintyDegree: 4}, nil)),
+			openskill.NewTeam(openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: 22, SkillUncertaintyDegree: 7}, nil), openskill.NewRating(nil, nil)),
+		}
+	}
+
+	for name, model := range models {
+		model := model
+
+		unweighted := openskill.Rate(newTeams(), openskill.Options{Model: &model, Rankings: []int64{2, 1}})
+		fullWeights := openskill.Rate(newTeams(), openskill.Options{Model: &model, Rankings: []int64{2, 1}, Weights: [][]float64{{1, 1}, {1, 1}}})
+		if !reflect.DeepEqual(snapshot(unweighted), snapshot(fullWeights)) {
+			t.Errorf("%s: expected weights of 1 to behave like no weights, got %v and %v", name, snapshot(unweighted), snapshot(fullWeights))
+		}
+
+		teams := newTeams()
+		partial, err := openskill.RateE(teams, openskill.Options{Model: &model, Rankings: []int64{2, 1}, Weights: [][]float64{{1, 0}, {0.5, 1}}})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", name, err)
+		}
+		if *partial[0][1] != *teams[0][1] {
+			t.Errorf("%s: expected a player with weight 0 to keep their rating, got %v", name, *partial[0][1])
+		}
+		fullGain := unweighted[1][0].AveragePlayerSkill - teams[1][0].AveragePlayerSkill
+		partialGain := partial[1][0].AveragePlayerSkill - teams[1][0].AveragePlayerSkill
+		if partialGain <= 0 || partialGain >= fullGain {
+			t.Errorf("%s: expected a partial player to gain less than a full one, got %f and %f", name, partialGain, fullGain)
+		}
+	}
+
+	if _, err := openskill.RateE(newTeams(), openskill.Options{Weights: [][]float64{{1, 1}}}); !errors.Is(err, openskill.ErrWeightsLength) {
+		t.Errorf("Expected %v, got %v", openskill.ErrWeightsLength, err)
+	}
+	if _, err := openskill.RateE(newTeams(), openskill.Options{Weights: [][]float64{{1, 1}, {0, 0}}}); !errors.Is(err, openskill.ErrInvalidWeight) {
+		t.Errorf("Expected %v, got %v", openskill.ErrInvalidWeight, err)
+	}
+}
+
+func TestRateMargin(t *testing.T) {
+	models := map[string]openskill.Model{
+		"PlackettLuce":           openskill.PlackettLuce,
+		"BradleyTerryFull":       openskill.BradleyTerryFull,
+		"BradleyTerryPart":       openskill.BradleyTerryPart,
+		"ThurstoneMostellerFull": openskill.ThurstoneMostellerFull,
+		"ThurstoneMostellerPart": openskill.ThurstoneMostellerPart,
+	}
+	margin := 2.0
+
+	newTeams := func() []openskill.Team {
+		return []openskill.Team{
+			openskill.NewTeam(openskill.NewRating(nil, nil)),
+			openskill.NewTeam(openskill.NewRating(nil, nil)),
+		}
+	}
+
+	for name, model := range models {
+		model := model
+
+		plain := openskill.Rate(newTeams(), openskill.Options{Model: &model, Scores: []int64{10, 0}})
+		narrow := openskill.Rate(newTeams(), openskill.Options{Model: &model, Scores: []int64{10, 9}, Margin: &margin})
+		decisive := openskill.Rate(newTeams(), openskill.Options{Model: &model, Scores: []int64{0, 10}, Margin: &margin})
+
+		if !reflect.DeepEqual(snapshot(plain), snapshot(narrow)) {
+			t.Errorf("%s: expected a win within the margin to behave like a regular win, got %v and %v", name, snapshot(plain), snapshot(narrow))
+		}
+		if decisive[1][0].AveragePlayerSkill <= plain[0][0].AveragePlayerSkill {
+			t.Errorf("%s: expected a decisive win to gain more than a regular one, got %f and %f", name, decisive[1][0].AveragePlayerSkill, plain[0][0].AveragePlayerSkill)
+		}
+		if decisive[0][0].AveragePlayerSkill >= plain[1][0].AveragePlayerSkill {
+			t.Errorf("%s: expected a decisive loss to lose more than a regular one, got %f and %f", name, decisive[0][0].AveragePlayerSkill, plain[1][0].AveragePlayerSkill)
+		}
+	}
+}
+
+func TestRateMarginSupport(t *testing.T) {
+	margin := 2.0
+	newTeams := func() []openskill.Team {
+		return []openskill.Team{
+			openskill.NewTeam(openskill.NewRating(nil, nil)),
+			openskill.NewTeam(openskill.NewRating(nil, nil)),
+		}
+	}
+
+	if _, err := openskill.RateE(newTeams(), openskill.Options{Scores: []int64{10, 0}, Margin: &margin}); err != nil {
+		t.Errorf("Expected the default model to accept a margin, got %v", err)
+	}
+
+	for name, model := range map[string]openskill.Model{"TrueSkill": openskill.TrueSkill, "Glicko2": openskill.Glicko2, "Elo": openskill.Elo} {
+		model := model
+		if _, err := openskill.RateE(newTeams(), openskill.Options{Model: &model, Scores: []int64{10, 0}, Margin: &margin}); !errors.Is(err, openskill.ErrInvalidOption) {
+			t.Errorf("%s: expected ErrInvalidOption, got %v", name, err)
+		}
+	}
+
+	period := openskill.NewRatingPeriod(&openskill.Options{Margin: &margin})
+	if err := period.AddGame(newTeams(), &openskill.Outcome{Scores: []int64{10, 0}}); !errors.Is(err, openskill.ErrInvalidOption) {
+		t.Errorf("Expected a rating period to reject a margin, got %v", err)
+	}
+}
+
+func TestPlackettLuceMarginAverage(t *testing.T) {
+	margin := 2.0
+	trace := &openskill.Trace{}
+	teams := []openskill.Team{
+		openskill.NewTeam(openskill.NewRating(nil, nil)),
+		openskill.NewTeam(openskill.NewRating(nil, nil)),
+		openskill.NewTeam(openskill.NewRating(nil, nil)),
+	}
+
+	openskill.Rate(teams, openskill.Options{Scores: []int64{30, 10, 0}, Margin: &margin, Trace: trace})
+
+	// the update of a team over the teams it outranked is scaled by the average of the factors
+	// against each of them, while each team ranked above it has its own factor.
+	expected := [][]float64{
+		{(2 + math.Log(10) + math.Log(15)) / 2},
+		{1 + math.Log(10), 1 + math.Log(5)},
+		{1 + math.Log(15), 1 + math.Log(5), 1},
+	}
+
+	for i, team := range trace.Teams {
+		if len(team.Opponents) != len(expected[i]) {
+			t.Fatalf("Expected team %d to be compared %d times, got %+v", i, len(expected[i]), team.Opponents)
+		}
+		for j, opponent := range team.Opponents {
+			if !withinTolerance(expected[i][j], opponent.Margin, 1e-12) {
+				t.Errorf("Expected the comparison %d of team %d to be scaled by %f, got %f", j, i, expected[i][j], opponent.Margin)
+			}
+		}
+	}
+}
+
+func TestRateThurstoneMostellerTie(t *testing.T) {
+	// with a wide draw margin, so the exact correction of a draw is used, a tie pulls the stronger
+	// player down and the weaker player up.
+	epsilon := 1.0
+	expected := map[string][2]float64{
+		"ThurstoneMostellerFull": {25.261222457376988, 22.665562367725443},
+		"ThurstoneMostellerPart": {28.813105551217546, 20.667628127440132},
+	}
+
+	for name, model := range map[string]openskill.Model{
+		"ThurstoneMostellerFull": openskill.ThurstoneMostellerFull,
+		"ThurstoneMostellerPart": openskill.ThurstoneMostellerPart,
+	} {
+		model := model
+		teams := []openskill.Team{
+			openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 30, SkillUncertaintyDegree: 8}),
+			openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 20, SkillUncertaintyDegree: 6}),
+		}
+
+		result := openskill.Rate(teams, openskill.Options{Model: &model, SmallPositive: &epsilon, Rankings: []int64{1, 1}})
+
+		for index, mu := range expected[name] {
+			if !withinTolerance(mu, result[index][0].AveragePlayerSkill, 1e-9) {
+				t.Errorf("%s: expected player %d to have mu %f, got %f", name, index, mu, result[index][0].AveragePlayerSkill)
+			}
+		}
+	}
+}
+
+func TestRateFloatOutcomes(t *testing.T) {
+	newTeams := func() []openskill.Team {
+		return []openskill.Team{
+			openskill.NewTeam(openskill.NewRating(nil, nil)),
+			openskill.NewTeam(openskill.NewRating(nil, nil)),
+			openskill.NewTeam(openskill.NewRating(nil, nil)),
+		}
+	}
+	tolerance := 0.05
+
+	intScores := openskill.Rate(newTeams(), openskill.Options{Scores: []int64{3, 9, 3}})
+	floatScores := openskill.Rate(newTeams(), openskill.Options{FloatScores: []float64{3.02, 9.5, 3}, TieTolerance: &tolerance})
+	if !reflect.DeepEqual(snapshot(intScores), snapshot(floatScores)) {
+		t.Errorf("Expected scores within the tolerance to tie, got %v and %v", snapshot(intScores), snapshot(floatScores))
+	}
+
+	intRankings := openskill.Rate(newTeams(), openskill.Options{Rankings: []int64{2, 3, 1}})
+	floatRankings := openskill.Rate(newTeams(), openskill.Options{FloatRankings: []float64{1.5, 2.25, 0.75}})
+	if !reflect.DeepEqual(snapshot(intRankings), snapshot(floatRankings)) {
+		t.Errorf("Expected fractional rankings to rank like integer ones, got %v and %v", snapshot(intRankings), snapshot(floatRankings))
+	}
+
+	if _, err := openskill.RateE(newTeams(), openskill.Options{FloatScores: []float64{1, math.NaN(), 2}}); !errors.Is(err, openskill.ErrInvalidOutcome) {
+		t.Errorf("Expected %v, got %v", openskill.ErrInvalidOutcome, err)
+	}
+}
+
+func TestRateDetailed(t *testing.T) {
+	tau := 0.5
+	options := openskill.Options{Rankings: []int64{2, 1}, Tau: &tau}
+	teams := []openskill.Team{
+		openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 30, SkillUncertaintyDegree: 5}, &openskill.Rating{AveragePlayerSkill: 28, SkillUncertaintyDegree: 4}),
+		openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 20, SkillUncertaintyDegree: 8}),
+	}
+	before := snapshot(teams)
+
+	changes, err := openskill.RateDetailed(teams, options)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	rated := openskill.Rate(teams, options)
+	probabilities := openskill.PredictWin(teams, &options)
+
+	for i, team := range changes {
+		for j, change := range team {
+			if change.Before != before[i][j] || change.After != *rated[i][j] {
+				t.Errorf("Expected player %d/%d to go from %v to %v, got %v to %v", i, j, before[i][j], *rated[i][j], change.Before, change.After)
+			}
+			if change.MuDelta != change.After.AveragePlayerSkill-change.Before.AveragePlayerSkill {
+				t.Errorf("Unexpected mu delta %v for player %d/%d", change.MuDelta, i, j)
+			}
+			if change.SigmaDelta != change.After.SkillUncertaintyDegree-change.Before.SkillUncertaintyDegree {
+				t.Errorf("Unexpected sigma delta %v for player %d/%d", change.SigmaDelta, i, j)
+			}
+			if expected := openskill.Ordinal(change.After, &options) - openskill.Ordinal(change.Before, &options); change.OrdinalDelta != expected {
+				t.Errorf("Expected an ordinal delta of %v for player %d/%d, got %v", expected, i, j, change.OrdinalDelta)
+			}
+			if change.WinProbability != probabilities[i] {
+				t.Errorf("Expected a win probability of %v for player %d/%d, got %v", probabilities[i], i, j, change.WinProbability)
+			}
+		}
+	}
+
+	// the underdogs won.
+	if changes[1][0].MuDelta <= 0 || changes[0][0].MuDelta >= 0 || changes[1][0].WinProbability >= 0.5 {
+		t.Errorf("Expected the underdogs to gain skill, got %+v", changes)
+	}
+
+	alone, err := openskill.RateDetailed([]openskill.Team{openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 30, SkillUncertaintyDegree: 5})}, openskill.Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(alone) != 1 || len(alone[0]) != 1 || alone[0][0].WinProbability != 1 {
+		t.Errorf("Expected a team alone to be certain to win, got %+v", alone)
+	}
+
+	if _, err := openskill.RateDetailed(nil, options); !errors.Is(err, openskill.ErrNoTeams) {
+		t.Errorf("Expected ErrNoTeams, got %v", err)
+	}
+}
@@ -0,0 +1,307 @@
+package openskill
+
+import (
+	"reflect"
+	"time"
+)
+
+// RaterOption configures a Rater created by NewRater.
+type RaterOption func(options *Options)
+
+// WithZ sets the constant used to compute the Ordinal of a rating. See Options.StandardizedPlayerSkill.
+func WithZ(z float64) RaterOption {
+	return func(options *Options) {
+		options.StandardizedPlayerSkill = &z
+	}
+}
+
+// WithMu sets the average skill of a new player. See Options.AveragePlayerSkill.
+func WithMu(mu float64) RaterOption {
+	return func(options *Options) {
+		options.AveragePlayerSkill = &mu
+	}
+}
+
+// WithSigma sets the skill uncertainty of a new player. See Options.SkillUncertaintyDegree.
+func WithSigma(sigma float64) RaterOption {
+	return func(options *Options) {
+		options.SkillUncertaintyDegree = &sigma
+	}
+}
+
+// WithBeta sets the standard deviation of a player performance. See Options.Beta.
+func WithBeta(beta float64) RaterOption {
+	return func(options *Options) {
+		options.Beta = &beta
+	}
+}
+
+// WithBetaSquared sets the variance of a team performance. See Options.VarianceForTeamPerformance.
+func WithBetaSquared(betaSq float64) RaterOption {
+	return func(options *Options) {
+		options.VarianceForTeamPerformance = &betaSq
+	}
+}
+
+// WithEpsilon sets the small positive value used when an uncertainty would become negative. See Options.SmallPositive.
+func WithEpsilon(epsilon float64) RaterOption {
+	return func(options *Options) {
+		options.SmallPositive = &epsilon
+	}
+}
+
+// WithTau sets the additive dynamics factor applied to the uncertainty before each game. See Options.Tau.
+func WithTau(tau float64) RaterOption {
+	return func(options *Options) {
+		options.Tau = &tau
+	}
+}
+
+// WithTauPerDay sets how much the uncertainty grows for each day without playing. See Options.TauPerDay.
+func WithTauPerDay(tau float64) RaterOption {
+	return func(options *Options) {
+		options.TauPerDay = &tau
+	}
+}
+
+// WithLimitSigma prevents the uncertainty of a player from increasing after a game when a tau is set.
+// See Options.PreventUncertaintyIncrease.
+func WithLimitSigma(limit bool) RaterOption {
+	return func(options *Options) {
+		options.PreventUncertaintyIncrease = &limit
+	}
+}
+
+// WithModel sets the ranking model. See Options.Model.
+func WithModel(model Model) RaterOption {
+	return func(options *Options) {
+		options.Model = &model
+	}
+}
+
+// WithGamma sets the function used to adjust how much the uncertainty can vary. See Options.GammaFunction.
+func WithGamma(gamma Gamma) RaterOption {
+	return func(options *Options) {
+		options.GammaFunction = &gamma
+	}
+}
+
+// WithDrawProbability sets the chance of a draw used by the TrueSkill model. See Options.DrawProbability.
+func WithDrawProbability(probability float64) RaterOption {
+	return func(options *Options) {
+		options.DrawProbability = &probability
+	}
+}
+
+// WithEloKFactor sets the maximum change of Elo rating in a single game, used by the Elo model.
+// See Options.EloKFactor.
+func WithEloKFactor(k float64) RaterOption {
+	return func(options *Options) {
+		options.EloKFactor = &k
+	}
+}
+
+// WithVolatility sets the volatility of a new player, used by the Glicko2 model. See Options.Volatility.
+func WithVolatility(volatility float64) RaterOption {
+	return func(options *Options) {
+		options.Volatility = &volatility
+	}
+}
+
+// WithVolatilityConstraint sets how much the volatility can change, used by the Glicko2 model.
+// See Options.VolatilityConstraint.
+func WithVolatilityConstraint(constraint float64) RaterOption {
+	return func(options *Options) {
+		options.VolatilityConstraint = &constraint
+	}
+}
+
+// WithMargin enables margin of victory aware updates. See Options.Margin.
+func WithMargin(margin float64) RaterOption {
+	return func(options *Options) {
+		options.Margin = &margin
+	}
+}
+
+// WithTieTolerance sets the largest difference between rankings or scores for them to be tied. See Options.TieTolerance.
+func WithTieTolerance(tolerance float64) RaterOption {
+	return func(options *Options) {
+		options.TieTolerance = &tolerance
+	}
+}
+
+// Outcome holds the result of a single match, to be used by Rater.Rate. Every field is optional
+// and follows the semantics of the field with the same name in Options.
+type Outcome struct {
+	Rankings      []int64     `json:"rankings,omitempty"`
+	Scores        []int64     `json:"scores,omitempty"`
+	FloatRankings []float64   `json:"floatRankings,omitempty"`
+	FloatScores   []float64   `json:"floatScores,omitempty"`
+	Weights       [][]float64 `json:"weights,omitempty"`
+	Trace         *Trace      `json:"-"`
+}
+
+// Rater holds a set of constants that are resolved and validated once, so every rating,
+// prediction and new player created through it is consistent. It is safe for concurrent use.
+type Rater struct {
+	options Options
+}
+
+// NewRater creates a Rater with the given options. Every constant that is not set falls back to
+// the same default used by the package level functions. It returns an error wrapping
+// ErrInvalidOption or ErrNilModel if any of the constants is out of its valid range.
+func NewRater(opts ...RaterOption) (*Rater, error) {
+	var options Options
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if err := validateOptions(&options); err != nil {
+		return nil, err
+	}
+
+	// the order matters, as the defaults of some constants depend on others.
+	resolvedZ := z(&options)
+	options.StandardizedPlayerSkill = &resolvedZ
+	resolvedMu := mu(&options)
+	options.AveragePlayerSkill = &resolvedMu
+	resolvedSigma := sigma(&options)
+	options.SkillUncertaintyDegree = &resolvedSigma
+	resolvedBeta := beta(&options)
+	options.Beta = &resolvedBeta
+	resolvedBetaSq := betaSq(&options)
+	options.VarianceForTeamPerformance = &resolvedBetaSq
+	resolvedEpsilon := epsilon(&options)
+	options.SmallPositive = &resolvedEpsilon
+	resolvedGamma := gamma(&options)
+	options.GammaFunction = &resolvedGamma
+
+	if options.Model == nil {
+		var model Model = PlackettLuce
+		options.Model = &model
+	}
+
+	if err := validateOptions(&options); err != nil {
+		return nil, err
+	}
+
+	return &Rater{options: options}, nil
+}
+
+// Options returns a copy of the resolved options of the rater, for use with the package level functions.
+// The copy is deep, so changing it doesn't change the rater.
+func (r *Rater) Options() Options {
+	return cloneOptions(r.options)
+}
+
+// Rate rates a group of teams with the outcome of their match. A nil outcome means the teams
+// are ranked in the order they were provided. The input is validated just like RateE does.
+func (r *Rater) Rate(teams []Team, outcome *Outcome) ([]Team, error) {
+	return RateE(teams, r.matchOptions(outcome))
+}
+
+// RateDetailed rates a group of teams with the outcome of their match, returning how the rating
+// of each player changed. See RateDetailed.
+func (r *Rater) RateDetailed(teams []Team, outcome *Outcome) ([][]RatingChange, error) {
+	return RateDetailed(teams, r.matchOptions(outcome))
+}
+
+// PredictWin returns the probability of each team to win. See PredictWin.
+func (r *Rater) PredictWin(teams []Team) []float64 {
+	return PredictWin(teams, &r.options)
+}
+
+// PredictDraw returns the probability of the teams to tie. See PredictDraw.
+func (r *Rater) PredictDraw(teams []Team) float64 {
+	return PredictDraw(teams, &r.options)
+}
+
+// PredictRank returns the predicted rank of each team and its probability. See PredictRank.
+func (r *Rater) PredictRank(teams []Team) [][]float64 {
+	return PredictRank(teams, &r.options)
+}
+
+// Ordinal returns the conservative estimate of the skill of a rating. See Ordinal.
+func (r *Rater) Ordinal(rating Rating) float64 {
+	return Ordinal(rating, &r.options)
+}
+
+// DecayRating grows the uncertainty of a rating after a period without playing. See DecayRating.
+func (r *Rater) DecayRating(rating Rating, elapsed time.Duration) Rating {
+	return DecayRating(rating, elapsed, &r.options)
+}
+
+// SeasonReset softly resets a set of ratings at a season boundary. See SeasonReset.
+func (r *Rater) SeasonReset(ratings []*Rating, params SeasonResetParams) ([]*Rating, error) {
+	return SeasonReset(ratings, params, &r.options)
+}
+
+// DisplayRating returns the value of a rating on a display scale. See DisplayRating.
+func (r *Rater) DisplayRating(rating Rating, scale DisplayScale) float64 {
+	return DisplayRating(rating, scale, &r.options)
+}
+
+// NewRating creates a new Rating, with optional initializing values. See NewRating.
+func (r *Rater) NewRating(init *NewRatingParams) *Rating {
+	return NewRating(init, &r.options)
+}
+
+func (r *Rater) matchOptions(outcome *Outcome) Options {
+	return outcomeOptions(r.options, outcome)
+}
+
+// outcomeOptions returns a copy of options with the fields of the outcome of a match.
+func outcomeOptions(options Options, outcome *Outcome) Options {
+	if outcome != nil {
+		options.Rankings = outcome.Rankings
+		options.Scores = outcome.Scores
+		options.FloatRankings = outcome.FloatRankings
+		options.FloatScores = outcome.FloatScores
+		options.Weights = outcome.Weights
+		options.Trace = outcome.Trace
+	}
+
+	return options
+}
+
+// cloneOptions returns a copy of options that shares no memory with it, except for Options.Trace,
+// which is meant to be filled by whoever holds it. The fields are copied by walking the struct,
+// so new options are copied without changes here.
+func cloneOptions(options Options) Options {
+	value := reflect.ValueOf(&options).Elem()
+	trace := reflect.TypeOf(options.Trace)
+
+	for i := 0; i < value.NumField(); i++ {
+		if field := value.Field(i); field.CanSet() && field.Type() != trace {
+			field.Set(deepCopy(field))
+		}
+	}
+
+	return options
+}
+
+// deepCopy returns a copy of a value made of pointers, slices and plain values that shares no
+// memory with it.
+func deepCopy(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Type().Elem())
+		copied.Elem().Set(deepCopy(value.Elem()))
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(deepCopy(value.Index(i)))
+		}
+		return copied
+	default:
+		return value
+	}
+}
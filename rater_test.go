@@ -0,0 +1,167 @@
+package openskill_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+func TestRater(t *testing.T) {
+	rater, err := openskill.NewRater()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	newTeams := func() []openskill.Team {
+		return []openskill.Team{
+			openskill.NewTeam(rater.NewRating(nil), rater.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: 30, SkillUncertaintyDegree: 4})),
+			openskill.NewTeam(rater.NewRating(nil), rater.NewRating(nil)),
+		}
+	}
+
+	rated, err := rater.Rate(newTeams(), &openskill.Outcome{Rankings: []int64{2, 1}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := openskill.Rate(newTeams(), openskill.Options{Rankings: []int64{2, 1}})
+	if !reflect.DeepEqual(snapshot(rated), snapshot(expected)) {
+		t.Errorf("Expected a default rater to rate like Rate, got %v and %v", snapshot(rated), snapshot(expected))
+	}
+
+	if !reflect.DeepEqual(rater.PredictWin(newTeams()), openskill.PredictWin(newTeams(), nil)) {
+		t.Errorf("Expected a default rater to predict like PredictWin")
+	}
+	if rater.PredictDraw(newTeams()) != openskill.PredictDraw(newTeams(), nil) {
+		t.Errorf("Expected a default rater to predict like PredictDraw")
+	}
+	if *rater.NewRating(nil) != *openskill.NewRating(nil, nil) {
+		t.Errorf("Expected a default rater to create ratings like NewRating")
+	}
+
+	custom, err := openskill.NewRater(openskill.WithMu(1500), openskill.WithZ(2), openskill.WithModel(openskill.BradleyTerryFull))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	rating := custom.NewRating(nil)
+	if rating.AveragePlayerSkill != 1500 || rating.SkillUncertaintyDegree != 750 || custom.Ordinal(*rating) != 0 {
+		t.Errorf("Expected the defaults to be derived from the options, got %v", *rating)
+	}
+
+	if _, err := openskill.NewRater(openskill.WithSigma(-1)); !errors.Is(err, openskill.ErrInvalidOption) {
+		t.Errorf("Expected %v, got %v", openskill.ErrInvalidOption, err)
+	}
+	if _, err := openskill.NewRater(openskill.WithModel(nil)); !errors.Is(err, openskill.ErrNilModel) {
+		t.Errorf("Expected %v, got %v", openskill.ErrNilModel, err)
+	}
+}
+
+func TestRaterOptionsIsolation(t *testing.T) {
+	rater, err := openskill.NewRater(openskill.WithTau(0.1), openskill.WithLimitSigma(true))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	before := *rater.NewRating(nil)
+
+	options := rater.Options()
+	*options.AveragePlayerSkill = 1000
+	*options.SkillUncertaintyDegree = 1
+	*options.Tau = 50
+	*options.PreventUncertaintyIncrease = false
+	*options.Model = openskill.BradleyTerryFull
+
+	if after := *rater.NewRating(nil); after != before {
+		t.Errorf("Expected the rater to be unchanged, got %v instead of %v", after, before)
+	}
+
+	again := rater.Options()
+	if *again.Tau != 0.1 || !*again.PreventUncertaintyIncrease || *again.AveragePlayerSkill != 25 {
+		t.Errorf("Expected the options of the rater to be unchanged, got %+v", again)
+	}
+
+	teams := []openskill.Team{openskill.NewTeam(rater.NewRating(nil)), openskill.NewTeam(rater.NewRating(nil))}
+	rated, err := rater.Rate(teams, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expected := openskill.Rate(teams, again); !reflect.DeepEqual(snapshot(rated), snapshot(expected)) {
+		t.Errorf("Expected the rater to keep its model, got %v and %v", snapshot(rated), snapshot(expected))
+	}
+}
+
+// filledOptions returns options with every field set to a distinct value, so the tests that walk
+// Options notice the fields they miss.
+func filledOptions(t *testing.T) openskill.Options {
+	t.Helper()
+
+	options := openskill.Options{}
+	value := reflect.ValueOf(&options).Elem()
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		number := float64(i + 1)
+		enabled := true
+		model := openskill.Model(openskill.BradleyTerryPart)
+		gamma := openskill.Gamma(func(float64, int64, float64, float64, *openskill.Team, int64) float64 { return number })
+
+		switch field.Interface().(type) {
+		case *float64:
+			field.Set(reflect.ValueOf(&number))
+		case *bool:
+			field.Set(reflect.ValueOf(&enabled))
+		case *openskill.Model:
+			field.Set(reflect.ValueOf(&model))
+		case *openskill.Gamma:
+			field.Set(reflect.ValueOf(&gamma))
+		case []int64:
+			field.Set(reflect.ValueOf([]int64{int64(i), int64(i + 1)}))
+		case []float64:
+			field.Set(reflect.ValueOf([]float64{number, number + 1}))
+		case [][]float64:
+			field.Set(reflect.ValueOf([][]float64{{number}, {number, number + 1}}))
+		case *openskill.Trace:
+			field.Set(reflect.ValueOf(&openskill.Trace{}))
+		default:
+			t.Fatalf("Expected filledOptions to know the type of Options.%s, got %v", value.Type().Field(i).Name, field.Type())
+		}
+	}
+
+	return options
+}
+
+// sameOptionField reports whether two values of a field of Options hold the same value.
+func sameOptionField(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Pointer && !a.IsNil() && !b.IsNil() && a.Elem().Kind() == reflect.Func {
+		return a.Elem().Pointer() == b.Elem().Pointer()
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+func TestCloneOptions(t *testing.T) {
+	options := filledOptions(t)
+	cloned := openskill.CloneOptions(options)
+
+	original, copied := reflect.ValueOf(options), reflect.ValueOf(cloned)
+	for i := 0; i < original.NumField(); i++ {
+		name := original.Type().Field(i).Name
+		a, b := original.Field(i), copied.Field(i)
+
+		if !sameOptionField(a, b) {
+			t.Errorf("Expected Options.%s to be copied, got %v instead of %v", name, reflect.Indirect(b), reflect.Indirect(a))
+		}
+
+		shared := a.Pointer() == b.Pointer()
+		if name == "Trace" && !shared {
+			t.Errorf("Expected Options.Trace to be shared")
+		}
+		if name != "Trace" && shared {
+			t.Errorf("Expected Options.%s not to be shared", name)
+		}
+	}
+
+	if &options.Weights[1][0] == &cloned.Weights[1][0] {
+		t.Errorf("Expected the weights of each team not to be shared")
+	}
+}
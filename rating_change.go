@@ -0,0 +1,54 @@
+package openskill
+
+// RatingChange describes how a single match changed the rating of a player.
+type RatingChange struct {
+	// Before is the rating of the player going into the match, as it was given, and After is the
+	// rating after the match. Any uncertainty added by Options.Tau is part of the change.
+	Before Rating
+	After  Rating
+
+	// MuDelta, SigmaDelta and OrdinalDelta are the changes of the skill, the uncertainty and the
+	// Ordinal of the player.
+	MuDelta      float64
+	SigmaDelta   float64
+	OrdinalDelta float64
+
+	// WinProbability is the probability of the team of the player winning the match, as given by
+	// PredictWin before the match was rated. It is 1 when the team played alone.
+	WinProbability float64
+}
+
+// RateDetailed rates a group of teams just like RateE does, but instead of the new ratings it
+// returns how the rating of each player changed, with the same shape as teams.
+func RateDetailed(teams []Team, options Options) ([][]RatingChange, error) {
+	rated, err := RateE(teams, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// a team alone always wins, and PredictWin has nothing to compare it against.
+	probabilities := []float64{1}
+	if len(teams) > 1 {
+		probabilities = PredictWin(teams, &options)
+	}
+
+	changes := make([][]RatingChange, len(teams))
+	for i, team := range teams {
+		changes[i] = make([]RatingChange, len(team))
+
+		for j, before := range team {
+			after := *rated[i][j]
+
+			changes[i][j] = RatingChange{
+				Before:         *before,
+				After:          after,
+				MuDelta:        after.AveragePlayerSkill - before.AveragePlayerSkill,
+				SigmaDelta:     after.SkillUncertaintyDegree - before.SkillUncertaintyDegree,
+				OrdinalDelta:   Ordinal(after, &options) - Ordinal(*before, &options),
+				WinProbability: probabilities[i],
+			}
+		}
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,519 @@
+package openskill
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/samber/lo"
+)
+
+// The reference implementations below are the straightforward versions of the models and of their
+// helpers, written with closures over freshly allocated slices. They follow the formulas of the
+// Weng-Lin paper closely, and the optimized versions are checked against them.
+
+type sums struct {
+	omegaSum float64
+	deltaSum float64
+}
+
+func referenceTeamRatings(options *Options) func(game []Team) []*teamRating {
+	return func(game []Team) []*teamRating {
+		var rank []int64
+		if options != nil && len(options.FloatRankings) > 0 {
+			rank = rankings(game, denseRanks(options.FloatRankings, tieTolerance(options)))
+		} else if options != nil && options.Rankings != nil {
+			rank = rankings(game, options.Rankings)
+		} else {
+			rank = rankings(game, []int64{})
+		}
+
+		return lo.Map(game, func(item Team, index int) *teamRating {
+			weights := teamWeights(options, index, len(item))
+
+			mu := lo.Sum(lo.Map([]*Rating(item), func(item *Rating, index int) float64 {
+				return weights[index] * item.AveragePlayerSkill
+			}))
+			sigma := lo.Sum(lo.Map([]*Rating(item), func(item *Rating, index int) float64 {
+				return math.Pow(weights[index]*item.SkillUncertaintyDegree, 2)
+			}))
+
+			return &teamRating{
+				Team:        &item,
+				TeamMu:      mu,
+				TeamSigmaSq: sigma,
+				Weights:     weights,
+				Rank:        rank[index],
+				Score:       referenceTeamScore(options, index),
+			}
+		})
+	}
+}
+
+func referenceTeamScore(options *Options, teamIndex int) float64 {
+	if scores := teamScores(options); teamIndex < len(scores) {
+		return scores[teamIndex]
+	}
+
+	return 0
+}
+
+func referenceUpdateTeam(item *teamRating, omega, delta, epsilon float64) Team {
+	return lo.Map([]*Rating(*item.Team), func(finalItem *Rating, index int) *Rating {
+		weight := item.Weights[index]
+		sigmaSq := math.Pow(finalItem.SkillUncertaintyDegree, 2)
+		mu := finalItem.AveragePlayerSkill + weight*(sigmaSq/item.TeamSigmaSq)*omega
+		sigma := finalItem.SkillUncertaintyDegree * math.Sqrt(math.Max(1-weight*weight*(sigmaSq/item.TeamSigmaSq)*delta, epsilon))
+
+		return &Rating{
+			AveragePlayerSkill:     mu,
+			SkillUncertaintyDegree: sigma,
+		}
+	})
+}
+
+func referenceLadderPairs[T any](slc []*T) [][]*T {
+	size := len(slc)
+
+	var left, right []*T = make([]*T, 0), make([]*T, 0)
+
+	// bail earlier
+	if size == 1 {
+		return [][]*T{}
+	}
+
+	left = append(left, nil)
+	left = append(left, slc[0:size-1]...)
+
+	right = append(right, slc[1:]...)
+	right = append(right, nil)
+
+	zip := lo.Zip2(left, right)
+
+	return lo.Map(zip, func(item lo.Tuple2[*T, *T], index int) []*T {
+		l, r := item.Unpack()
+
+		if l != nil && r != nil {
+			return []*T{l, r}
+		}
+		if l != nil && r == nil {
+			return []*T{l}
+		}
+		if l == nil && r != nil {
+			return []*T{r}
+		}
+
+		return []*T{} // this should really only happen when size == 1
+	})
+}
+
+func referenceUtilC(options *Options) func(teamRatings []*teamRating) float64 {
+	betasq := betaSq(options)
+
+	return func(teamRatings []*teamRating) float64 {
+		return math.Sqrt(
+			lo.Sum(lo.Map(teamRatings, func(item *teamRating, index int) float64 {
+				return item.TeamSigmaSq + betasq
+			})),
+		)
+	}
+}
+
+func referenceUtilSumQ(teamRatings []*teamRating, c float64) []float64 {
+	return lo.Map(teamRatings, func(item *teamRating, index int) float64 {
+		filteredRatings := lo.Filter(teamRatings, func(localItem *teamRating, index int) bool {
+			return localItem.Rank >= item.Rank
+		})
+		mappedFilteredRatings := lo.Map(filteredRatings, func(localItem *teamRating, index int) float64 {
+			return math.Exp(localItem.TeamMu / c)
+		})
+
+		return lo.Sum(mappedFilteredRatings)
+	})
+}
+
+func referenceUtilA(teamRatings []*teamRating) []int64 {
+	return lo.Map(teamRatings, func(item *teamRating, index int) int64 {
+		filteredRatings := lo.Filter(teamRatings, func(localItem *teamRating, index int) bool {
+			return item.Rank == localItem.Rank
+		})
+
+		return int64(len(filteredRatings))
+	})
+}
+
+func referencePlackettLuce(game []Team, options *Options) []Team {
+	epsilon := epsilon(options)
+	teamRatings := referenceTeamRatings(options)(game)
+	c := referenceUtilC(options)(teamRatings)
+	sumQ := referenceUtilSumQ(teamRatings, c)
+	a := referenceUtilA(teamRatings)
+	gamma := gamma(options)
+	margin := marginFactor(options)
+	trace := newTracer(options, "PlackettLuce", teamRatings)
+
+	return lo.Map(teamRatings, func(item *teamRating, index int) Team {
+		iMuOverCe := math.Exp(item.TeamMu / c)
+		iGamma := gamma(c, int64(len(teamRatings)), item.TeamMu, item.TeamSigmaSq, item.Team, item.Rank)
+
+		filteredRatings := lo.Filter(teamRatings, func(localItem *teamRating, localIndex int) bool {
+			return localItem.Rank <= item.Rank
+		})
+
+		// When a team beats the ones ranked below it, the update is scaled by the average
+		// margin of victory over those teams.
+		outrankedRatings := lo.Filter(teamRatings, func(localItem *teamRating, localIndex int) bool {
+			return localItem.Rank > item.Rank
+		})
+		outranked := lo.Ternary(len(outrankedRatings) > 0, lo.Sum(lo.Map(outrankedRatings, func(localItem *teamRating, localIndex int) float64 {
+			return margin(item, localItem)
+		}))/float64(len(outrankedRatings)), 1)
+
+		_sums := lo.Reduce(filteredRatings, func(agg sums, localItem *teamRating, localIndex int) sums {
+			quotient := iMuOverCe / sumQ[localIndex]
+
+			var factor, omega float64
+			if index == localIndex {
+				factor = outranked
+				omega = outranked * (1 - quotient) / float64(a[localIndex])
+			} else {
+				factor = margin(item, localItem)
+				omega = factor * -quotient / float64(a[localIndex])
+			}
+			delta := (quotient * (1 - quotient)) / float64(a[localIndex])
+
+			agg.omegaSum = agg.omegaSum + omega
+			agg.deltaSum = agg.deltaSum + delta
+
+			trace.opponent(index, localItem, OpponentTrace{
+				C:      c,
+				Margin: factor,
+				Omega:  omega * (item.TeamSigmaSq / c),
+				Delta:  iGamma * delta * (item.TeamSigmaSq / math.Pow(c, 2)),
+			})
+
+			return agg
+		}, sums{omegaSum: 0, deltaSum: 0})
+
+		iOmega := _sums.omegaSum * (item.TeamSigmaSq / c)
+		iDelta := iGamma * _sums.deltaSum * (item.TeamSigmaSq / math.Pow(c, 2))
+
+		if team := trace.team(index); team != nil {
+			team.C, team.SumQ, team.A, team.Gamma = c, sumQ[index], a[index], iGamma
+		}
+		trace.update(index, iOmega, iDelta)
+
+		return referenceUpdateTeam(item, iOmega, iDelta, epsilon)
+	})
+}
+
+func referenceBradleyTerryFull(game []Team, options *Options) []Team {
+	epsilon := epsilon(options)
+	tbs := betaSq(options) * 2
+	_gamma := gamma(options)
+	_margin := marginFactor(options)
+
+	teamRatings := referenceTeamRatings(options)(game)
+	trace := newTracer(options, "BradleyTerryFull", teamRatings)
+
+	return lo.Map(teamRatings, func(item *teamRating, index int) Team {
+		var iMu, iSigmaSq, iRank = item.TeamMu, item.TeamSigmaSq, item.Rank
+
+		filteredRatings := lo.Filter(teamRatings, func(localItem *teamRating, localIndex int) bool {
+			return localIndex != index
+		})
+
+		_sums := lo.Reduce(filteredRatings, func(agg sums, localItem *teamRating, localIndex int) sums {
+			var qMu, qSigmaSq, qRank = localItem.TeamMu, localItem.TeamSigmaSq, localItem.Rank
+
+			ciq := math.Sqrt(iSigmaSq + qSigmaSq + tbs)
+			piq := 1 / (1 + math.Exp((qMu-iMu)/ciq))
+
+			sigSqToCiq := iSigmaSq / ciq
+
+			iGamma := _gamma(ciq, int64(len(teamRatings)), item.TeamMu, item.TeamSigmaSq, item.Team, item.Rank)
+
+			margin := _margin(item, localItem)
+			omega := margin * sigSqToCiq * (score(qRank, iRank) - piq)
+			delta := ((iGamma * sigSqToCiq) / ciq) * piq * (1 - piq)
+
+			agg.omegaSum += omega
+			agg.deltaSum += delta
+
+			trace.opponent(index, localItem, OpponentTrace{C: ciq, Gamma: iGamma, Margin: margin, Omega: omega, Delta: delta})
+
+			return agg
+		}, sums{omegaSum: 0, deltaSum: 0})
+
+		trace.update(index, _sums.omegaSum, _sums.deltaSum)
+
+		return referenceUpdateTeam(item, _sums.omegaSum, _sums.deltaSum, epsilon)
+	})
+}
+
+func referenceBradleyTerryPart(game []Team, options *Options) []Team {
+	epsilon := epsilon(options)
+	tbs := betaSq(options) * 2
+	_gamma := gamma(options)
+	_margin := marginFactor(options)
+
+	teamRatings := referenceTeamRatings(options)(game)
+	adjacentTeams := referenceLadderPairs(teamRatings)
+	trace := newTracer(options, "BradleyTerryPart", teamRatings)
+
+	zipper := lo.Zip2(teamRatings, adjacentTeams)
+
+	return lo.Map(zipper, func(item lo.Tuple2[*teamRating, []*teamRating], index int) Team {
+		iTeamRating, iAdjacents := item.Unpack()
+
+		var iMu, iSigmaSq, iRank = iTeamRating.TeamMu, iTeamRating.TeamSigmaSq, iTeamRating.Rank
+
+		_sums := lo.Reduce(iAdjacents, func(agg sums, localItem *teamRating, localIndex int) sums {
+			var qMu, qSigmaSq, qRank = localItem.TeamMu, localItem.TeamSigmaSq, localItem.Rank
+
+			ciq := math.Sqrt(iSigmaSq + qSigmaSq + tbs)
+			piq := 1 / (1 + math.Exp((qMu-iMu)/ciq))
+			sigSqToCiq := iSigmaSq / ciq
+			iGamma := _gamma(ciq, int64(len(teamRatings)), iTeamRating.TeamMu, iTeamRating.TeamSigmaSq, iTeamRating.Team, iTeamRating.Rank)
+
+			margin := _margin(iTeamRating, localItem)
+			omega := margin * sigSqToCiq * (score(qRank, iRank) - piq)
+			delta := ((iGamma * sigSqToCiq) / ciq) * piq * (1 - piq)
+
+			agg.omegaSum += omega
+			agg.deltaSum += delta
+
+			trace.opponent(index, localItem, OpponentTrace{C: ciq, Gamma: iGamma, Margin: margin, Omega: omega, Delta: delta})
+
+			return agg
+		}, sums{omegaSum: 0, deltaSum: 0})
+
+		trace.update(index, _sums.omegaSum, _sums.deltaSum)
+
+		return referenceUpdateTeam(iTeamRating, _sums.omegaSum, _sums.deltaSum, epsilon)
+	})
+}
+
+func referenceThurstoneMostellerFull(game []Team, options *Options) []Team {
+	epsilon := epsilon(options)
+	tbs := betaSq(options) * 2
+	_gamma := gamma(options)
+	_margin := marginFactor(options)
+
+	teamRatings := referenceTeamRatings(options)(game)
+	trace := newTracer(options, "ThurstoneMostellerFull", teamRatings)
+
+	return lo.Map(teamRatings, func(iTeamRating *teamRating, index int) Team {
+		var iMu, iSigmaSq, iRank = iTeamRating.TeamMu, iTeamRating.TeamSigmaSq, iTeamRating.Rank
+
+		filteredRatings := lo.Filter(teamRatings, func(localItem *teamRating, localIndex int) bool {
+			return localIndex != index
+		})
+
+		_sums := lo.Reduce(filteredRatings, func(agg sums, localItem *teamRating, localIndex int) sums {
+			var qMu, qSigmaSq, qRank = localItem.TeamMu, localItem.TeamSigmaSq, localItem.Rank
+			ciq := math.Sqrt(iSigmaSq + qSigmaSq + tbs)
+			deltaMu := (iMu - qMu) / ciq
+			sigSqToCiq := iSigmaSq / ciq
+
+			iGamma := _gamma(ciq, int64(len(teamRatings)), iTeamRating.TeamMu, iTeamRating.TeamSigmaSq, iTeamRating.Team, iTeamRating.Rank)
+
+			margin, omega, delta := 1.0, 0.0, 0.0
+
+			if qRank == iRank {
+				omega = sigSqToCiq * vt(deltaMu, epsilon/ciq)
+				delta = ((iGamma * sigSqToCiq) / ciq) * wt(deltaMu, epsilon/ciq)
+			} else {
+				sign := lo.Ternary(qRank > iRank, 1.0, -1.0)
+
+				margin = _margin(iTeamRating, localItem)
+				omega = margin * sign * sigSqToCiq * v(sign*deltaMu, epsilon/ciq)
+				delta = ((iGamma * sigSqToCiq) / ciq) * w(sign*deltaMu, epsilon/ciq)
+			}
+
+			agg.omegaSum += omega
+			agg.deltaSum += delta
+
+			trace.opponent(index, localItem, OpponentTrace{C: ciq, Gamma: iGamma, Margin: margin, Omega: omega, Delta: delta})
+
+			return agg
+		}, sums{omegaSum: 0, deltaSum: 0})
+
+		trace.update(index, _sums.omegaSum, _sums.deltaSum)
+
+		return referenceUpdateTeam(iTeamRating, _sums.omegaSum, _sums.deltaSum, epsilon)
+	})
+}
+
+func referenceThurstoneMostellerPart(game []Team, options *Options) []Team {
+	epsilon := epsilon(options)
+	tbs := betaSq(options) * 2
+	_gamma := gamma(options)
+	_margin := marginFactor(options)
+
+	teamRatings := referenceTeamRatings(options)(game)
+	adjacentTeams := referenceLadderPairs(teamRatings)
+	trace := newTracer(options, "ThurstoneMostellerPart", teamRatings)
+
+	zipper := lo.Zip2(teamRatings, adjacentTeams)
+
+	return lo.Map(zipper, func(item lo.Tuple2[*teamRating, []*teamRating], index int) Team {
+		iTeamRating, iAdjacents := item.Unpack()
+
+		var iMu, iSigmaSq, iRank = iTeamRating.TeamMu, iTeamRating.TeamSigmaSq, iTeamRating.Rank
+
+		_sums := lo.Reduce(iAdjacents, func(agg sums, localItem *teamRating, localIndex int) sums {
+			var qMu, qSigmaSq, qRank = localItem.TeamMu, localItem.TeamSigmaSq, localItem.Rank
+
+			ciq := 2 * math.Sqrt(iSigmaSq+qSigmaSq+tbs)
+			deltaMu := (iMu - qMu) / ciq
+			sigSqToCiq := iSigmaSq / ciq
+			iGamma := _gamma(ciq, int64(len(teamRatings)), iTeamRating.TeamMu, iTeamRating.TeamSigmaSq, iTeamRating.Team, iTeamRating.Rank)
+
+			margin, omega, delta := 1.0, 0.0, 0.0
+
+			if qRank == iRank {
+				omega = sigSqToCiq * vt(deltaMu, epsilon/ciq)
+				delta = ((iGamma * sigSqToCiq) / ciq) * wt(deltaMu, epsilon/ciq)
+			} else {
+				sign := lo.Ternary(qRank > iRank, 1.0, -1.0)
+
+				margin = _margin(iTeamRating, localItem)
+				omega = margin * sign * sigSqToCiq * v(sign*deltaMu, epsilon/ciq)
+				delta = ((iGamma * sigSqToCiq) / ciq) * w(sign*deltaMu, epsilon/ciq)
+			}
+
+			agg.omegaSum += omega
+			agg.deltaSum += delta
+
+			trace.opponent(index, localItem, OpponentTrace{C: ciq, Gamma: iGamma, Margin: margin, Omega: omega, Delta: delta})
+
+			return agg
+		}, sums{omegaSum: 0, deltaSum: 0})
+
+		trace.update(index, _sums.omegaSum, _sums.deltaSum)
+
+		return referenceUpdateTeam(iTeamRating, _sums.omegaSum, _sums.deltaSum, epsilon)
+	})
+}
+
+// referenceModels pairs each optimized model with its reference implementation.
+var referenceModels = []struct {
+	name      string
+	model     Model
+	reference Model
+}{
+	{"PlackettLuce", PlackettLuce, referencePlackettLuce},
+	{"BradleyTerryFull", BradleyTerryFull, referenceBradleyTerryFull},
+	{"BradleyTerryPart", BradleyTerryPart, referenceBradleyTerryPart},
+	{"ThurstoneMostellerFull", ThurstoneMostellerFull, referenceThurstoneMostellerFull},
+	{"ThurstoneMostellerPart", ThurstoneMostellerPart, referenceThurstoneMostellerPart},
+}
+
+// randomGame returns a game between teams of one to four players, with random rankings that
+// include ties, and random scores and weights for half of the games.
+func randomGame(rng *rand.Rand, size int) ([]Team, Options) {
+	teams := make([]Team, size)
+	rankings := make([]int64, size)
+	scores := make([]int64, size)
+	weights := make([][]float64, size)
+
+	for i := range teams {
+		players := 1 + rng.Intn(4)
+		for j := 0; j < players; j++ {
+			teams[i] = append(teams[i], &Rating{AveragePlayerSkill: 10 + 30*rng.Float64(), SkillUncertaintyDegree: 1 + 7*rng.Float64()})
+			weights[i] = append(weights[i], 0.25+0.75*rng.Float64())
+		}
+		rankings[i] = int64(rng.Intn(size/2 + 1))
+		scores[i] = int64(rng.Intn(20))
+	}
+
+	if rng.Intn(2) == 0 {
+		return teams, Options{Rankings: rankings}
+	}
+
+	margin := 3.0
+	return teams, Options{Scores: scores, Margin: &margin, Weights: weights}
+}
+
+// closeEnough tells whether two values are equal up to the rounding of a different summation order.
+func closeEnough(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-12*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+func TestOptimizedModels(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, size := range []int{1, 2, 3, 5, 10, 25, 100} {
+		for round := 0; round < 20; round++ {
+			teams, options := randomGame(rng, size)
+
+			for _, item := range referenceModels {
+				model, reference := item.model, item.reference
+				optimizedTrace, referenceTrace := &Trace{}, &Trace{}
+
+				optimizedOptions, referenceOptions := options, options
+				optimizedOptions.Model, optimizedOptions.Trace = &model, optimizedTrace
+				referenceOptions.Model, referenceOptions.Trace = &reference, referenceTrace
+
+				optimized := Rate(teams, optimizedOptions)
+				expected := Rate(teams, referenceOptions)
+
+				for i := range expected {
+					for j := range expected[i] {
+						if !closeEnough(optimized[i][j].AveragePlayerSkill, expected[i][j].AveragePlayerSkill) || !closeEnough(optimized[i][j].SkillUncertaintyDegree, expected[i][j].SkillUncertaintyDegree) {
+							t.Fatalf("%s, %d teams: expected player %d/%d to be rated %v, got %v", item.name, size, i, j, *expected[i][j], *optimized[i][j])
+						}
+					}
+				}
+
+				for i, team := range referenceTrace.Teams {
+					other := optimizedTrace.Teams[i]
+					if team.Index != other.Index || len(team.Opponents) != len(other.Opponents) || !closeEnough(team.Omega, other.Omega) || !closeEnough(team.Delta, other.Delta) || !closeEnough(team.SumQ, other.SumQ) || team.A != other.A {
+						t.Fatalf("%s, %d teams: expected the trace of team %d to be %+v, got %+v", item.name, size, i, team, other)
+					}
+				}
+			}
+
+			teamRatings := teamRatings(&options)(teams)
+			c := utilC(&options)(teamRatings)
+			if !closeEnough(c, referenceUtilC(&options)(teamRatings)) {
+				t.Fatalf("%d teams: unexpected c %v", size, c)
+			}
+			if a, expected := utilA(teamRatings), referenceUtilA(teamRatings); fmt.Sprint(a) != fmt.Sprint(expected) {
+				t.Fatalf("%d teams: expected a to be %v, got %v", size, expected, a)
+			}
+			for i, sumQ := range referenceUtilSumQ(teamRatings, c) {
+				if optimized := utilSumQ(teamRatings, c)[i]; !closeEnough(sumQ, optimized) {
+					t.Fatalf("%d teams: expected sumQ %d to be %v, got %v", size, i, sumQ, optimized)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkModels(b *testing.B) {
+	for _, size := range []int{2, 10, 100} {
+		teams, options := randomGame(rand.New(rand.NewSource(int64(size))), size)
+
+		for _, item := range referenceModels {
+			for _, variant := range []struct {
+				name  string
+				model Model
+			}{{"reference", item.reference}, {"optimized", item.model}} {
+				model := variant.model
+				options := options
+				options.Model = &model
+
+				b.Run(fmt.Sprintf("%s/%d/%s", item.name, size, variant.name), func(b *testing.B) {
+					b.ReportAllocs()
+
+					for i := 0; i < b.N; i++ {
+						Rate(teams, options)
+					}
+				})
+			}
+		}
+	}
+}
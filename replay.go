@@ -0,0 +1,191 @@
+package openskill
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MatchRecord is a match of a MatchLog: the players of each team, the outcome, following the
+// semantics of Outcome, and when the match was played. Outcome.Trace is not part of the record.
+type MatchRecord[ID comparable] struct {
+	Teams [][]ID `json:"teams"`
+	Outcome
+	Time time.Time `json:"time"`
+}
+
+// MatchLog is the history of every match rated with a set of options, from which all the
+// ratings can be computed again. It can be serialized to JSON, as long as the identifiers can,
+// to be persisted and replayed later. The model is stored by name, so it must be one of the
+// models of this package, and Options.GammaFunction must not be set. The fields of Options that
+// describe a single match, such as Options.Rankings, are not stored, as they belong to the matches.
+type MatchLog[ID comparable] struct {
+	Options Options
+	Matches []MatchRecord[ID]
+}
+
+// namedModels holds the models of this package, by the name a MatchLog stores them with.
+var namedModels = map[string]Model{
+	"PlackettLuce":           PlackettLuce,
+	"BradleyTerryFull":       BradleyTerryFull,
+	"BradleyTerryPart":       BradleyTerryPart,
+	"ThurstoneMostellerFull": ThurstoneMostellerFull,
+	"ThurstoneMostellerPart": ThurstoneMostellerPart,
+	"TrueSkill":              TrueSkill,
+	"Elo":                    Elo,
+	"Glicko2":                Glicko2,
+}
+
+// logOptions is how the options of a MatchLog are serialized: the fields of Options that can be,
+// plus the name of the model.
+type logOptions struct {
+	Options
+	Model string `json:"model,omitempty"`
+}
+
+// logJSON is how a MatchLog is serialized.
+type logJSON[ID comparable] struct {
+	Options logOptions        `json:"options"`
+	Matches []MatchRecord[ID] `json:"matches"`
+}
+
+// MarshalJSON serializes the log. It returns an error wrapping ErrNotSerializable when the
+// options hold a custom Model or GammaFunction.
+func (l MatchLog[ID]) MarshalJSON() ([]byte, error) {
+	options := l.Options
+
+	if options.GammaFunction != nil {
+		return nil, fmt.Errorf("%w: GammaFunction is set", ErrNotSerializable)
+	}
+
+	var name string
+	if options.Model != nil {
+		for candidate, model := range namedModels {
+			if isModel(*options.Model, []Model{model}) {
+				name = candidate
+			}
+		}
+
+		if name == "" {
+			return nil, fmt.Errorf("%w: the model is not one of this package", ErrNotSerializable)
+		}
+	}
+
+	return json.Marshal(logJSON[ID]{
+		Options: logOptions{Options: options, Model: name},
+		Matches: l.Matches,
+	})
+}
+
+// UnmarshalJSON deserializes a log written by MarshalJSON. It returns an error wrapping
+// ErrNotSerializable when the model is not one of this package.
+func (l *MatchLog[ID]) UnmarshalJSON(data []byte) error {
+	var decoded logJSON[ID]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	options := decoded.Options.Options
+
+	if name := decoded.Options.Model; name != "" {
+		model, ok := namedModels[name]
+		if !ok {
+			return fmt.Errorf("%w: unknown model %q", ErrNotSerializable, name)
+		}
+		options.Model = &model
+	}
+
+	l.Options = options
+	l.Matches = decoded.Matches
+
+	return nil
+}
+
+// Append adds a match to the log.
+func (l *MatchLog[ID]) Append(teams [][]ID, outcome *Outcome, at time.Time) {
+	record := MatchRecord[ID]{Teams: teams, Time: at}
+	if outcome != nil {
+		record.Outcome = *outcome
+	}
+
+	l.Matches = append(l.Matches, record)
+}
+
+// ReplaySnapshot holds the ratings of the players of a match right after it was rated.
+type ReplaySnapshot[ID comparable] struct {
+	// Index is the position of the match in the log.
+	Index   int
+	Time    time.Time
+	Ratings map[ID]Rating
+}
+
+// ReplayResult is the result of replaying a MatchLog.
+type ReplayResult[ID comparable] struct {
+	// Ratings holds the final rating of every player in the log.
+	Ratings map[ID]Rating
+	// Snapshots holds a snapshot for each match, in the order they were rated, when requested.
+	Snapshots []ReplaySnapshot[ID]
+}
+
+// Replay rates every match of the log again, in chronological order, with matches played at the
+// same time rated in the order they were logged. Players start with the default rating of the
+// options, and their ratings decay with DecayRating for the time between their matches. When
+// options is nil, the options of the log are used, which reproduces the original ratings; passing
+// other options shows what the ratings would have been under them. The result is the same on
+// every run, so it can be used to evaluate and backfill tuning changes.
+func Replay[ID comparable](log *MatchLog[ID], options *Options, snapshots bool) (*ReplayResult[ID], error) {
+	if options == nil {
+		options = &log.Options
+	}
+
+	order := make([]int, len(log.Matches))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return log.Matches[order[i]].Time.Before(log.Matches[order[j]].Time)
+	})
+
+	result := &ReplayResult[ID]{Ratings: make(map[ID]Rating)}
+	lastPlayed := make(map[ID]time.Time)
+
+	for _, index := range order {
+		match := log.Matches[index]
+
+		rosters := make([]Roster[ID], len(match.Teams))
+		for i, team := range match.Teams {
+			roster := make(Roster[ID], len(team))
+
+			for j, id := range team {
+				rating, ok := result.Ratings[id]
+				if ok {
+					rating = DecayRating(rating, match.Time.Sub(lastPlayed[id]), options)
+				} else {
+					rating = *NewRating(nil, options)
+				}
+
+				roster[j] = Player[ID]{ID: id, Rating: rating}
+			}
+
+			rosters[i] = roster
+		}
+
+		ratings, err := RateRosters(rosters, outcomeOptions(*options, &match.Outcome))
+		if err != nil {
+			return nil, fmt.Errorf("replaying match %d: %w", index, err)
+		}
+
+		for id, rating := range ratings {
+			result.Ratings[id] = rating
+			lastPlayed[id] = match.Time
+		}
+
+		if snapshots {
+			result.Snapshots = append(result.Snapshots, ReplaySnapshot[ID]{Index: index, Time: match.Time, Ratings: ratings})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,191 @@
+package openskill_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+func TestReplay(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	log := &openskill.MatchLog[string]{}
+	// logged out of order, the second match was played first.
+	log.Append([][]string{{"alice"}, {"bob"}}, nil, start.Add(time.Hour))
+	log.Append([][]string{{"bob"}, {"carol"}}, &openskill.Outcome{Rankings: []int64{2, 1}}, start)
+	log.Append([][]string{{"carol"}, {"alice"}}, &openskill.Outcome{Scores: []int64{3, 3}}, start.Add(time.Hour))
+
+	result, err := openskill.Replay(log, nil, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// rate the same matches by hand, in chronological order.
+	ratings := map[string]openskill.Rating{}
+	rate := func(teams [][]string, options openskill.Options) {
+		rosters := []openskill.Roster[string]{}
+		for _, team := range teams {
+			roster := openskill.Roster[string]{}
+			for _, id := range team {
+				rating, ok := ratings[id]
+				if !ok {
+					rating = *openskill.NewRating(nil, nil)
+				}
+				roster = append(roster, openskill.Player[string]{ID: id, Rating: rating})
+			}
+			rosters = append(rosters, roster)
+		}
+
+		rated, err := openskill.RateRosters(rosters, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for id, rating := range rated {
+			ratings[id] = rating
+		}
+	}
+
+	rate([][]string{{"bob"}, {"carol"}}, openskill.Options{Rankings: []int64{2, 1}})
+	rate([][]string{{"alice"}, {"bob"}}, openskill.Options{})
+	rate([][]string{{"carol"}, {"alice"}}, openskill.Options{Scores: []int64{3, 3}})
+
+	for id, rating := range ratings {
+		if result.Ratings[id] != rating {
+			t.Errorf("Expected %s to be rated %v, got %v", id, rating, result.Ratings[id])
+		}
+	}
+
+	if len(result.Snapshots) != 3 {
+		t.Fatalf("Expected 3 snapshots, got %d", len(result.Snapshots))
+	}
+	for i, index := range []int{1, 0, 2} {
+		if result.Snapshots[i].Index != index {
+			t.Errorf("Expected snapshot %d to be of match %d, got %d", i, index, result.Snapshots[i].Index)
+		}
+	}
+	if result.Snapshots[2].Ratings["alice"] != result.Ratings["alice"] {
+		t.Errorf("Expected the last snapshot to hold the final rating of alice")
+	}
+
+	again, err := openskill.Replay(log, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, rating := range result.Ratings {
+		if again.Ratings[id] != rating {
+			t.Errorf("Expected replays to be deterministic, got %v and %v for %s", rating, again.Ratings[id], id)
+		}
+	}
+	if again.Snapshots != nil {
+		t.Errorf("Expected no snapshots, got %v", again.Snapshots)
+	}
+
+	// other options give other ratings.
+	model := openskill.Model(openskill.ThurstoneMostellerFull)
+	tuned, err := openskill.Replay(log, &openskill.Options{Model: &model}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tuned.Ratings["carol"] == result.Ratings["carol"] {
+		t.Errorf("Expected a different model to change the ratings")
+	}
+
+	log.Append([][]string{{"alice"}, {"alice"}}, nil, start.Add(2*time.Hour))
+	if _, err := openskill.Replay(log, nil, false); !errors.Is(err, openskill.ErrDuplicatePlayer) {
+		t.Errorf("Expected ErrDuplicatePlayer, got %v", err)
+	}
+}
+
+func TestMatchLogJSON(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	model := openskill.Model(openskill.ThurstoneMostellerFull)
+	tau := 0.1
+
+	log := &openskill.MatchLog[string]{Options: openskill.Options{Model: &model, Tau: &tau}}
+	log.Append([][]string{{"alice"}, {"bob"}}, nil, start)
+	log.Append([][]string{{"bob"}, {"carol"}}, &openskill.Outcome{Rankings: []int64{2, 1}, Trace: &openskill.Trace{}}, start.Add(time.Hour))
+	log.Append([][]string{{"carol"}, {"alice"}}, &openskill.Outcome{Scores: []int64{3, 3}}, start.Add(2*time.Hour))
+
+	data, err := json.Marshal(log)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	decoded := &openskill.MatchLog[string]{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if decoded.Matches[1].Trace != nil {
+		t.Errorf("Expected the trace not to be serialized")
+	}
+
+	expected, err := openskill.Replay(log, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := openskill.Replay(decoded, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, rating := range expected.Ratings {
+		if actual.Ratings[id] != rating {
+			t.Errorf("Expected %s to be rated %v after a round trip, got %v", id, rating, actual.Ratings[id])
+		}
+	}
+
+	custom := openskill.Model(func(game []openskill.Team, options *openskill.Options) []openskill.Team { return game })
+	if _, err := json.Marshal(&openskill.MatchLog[string]{Options: openskill.Options{Model: &custom}}); !errors.Is(err, openskill.ErrNotSerializable) {
+		t.Errorf("Expected ErrNotSerializable for a custom model, got %v", err)
+	}
+
+	unknown := &openskill.MatchLog[string]{}
+	if err := json.Unmarshal([]byte(`{"options":{"model":"Unknown"},"matches":[]}`), unknown); !errors.Is(err, openskill.ErrNotSerializable) {
+		t.Errorf("Expected ErrNotSerializable for an unknown model, got %v", err)
+	}
+}
+
+func TestMatchLogJSONOptions(t *testing.T) {
+	// The fields of Options that a MatchLog does not store, either because they cannot be
+	// serialized or because they describe a single match.
+	unstored := map[string]bool{
+		"GammaFunction": true,
+		"Rankings":      true,
+		"Scores":        true,
+		"FloatRankings": true,
+		"FloatScores":   true,
+		"Weights":       true,
+		"Trace":         true,
+	}
+
+	options := filledOptions(t)
+	options.GammaFunction = nil
+
+	data, err := json.Marshal(&openskill.MatchLog[string]{Options: options})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	decoded := &openskill.MatchLog[string]{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	original, restored := reflect.ValueOf(options), reflect.ValueOf(decoded.Options)
+	for i := 0; i < original.NumField(); i++ {
+		name := original.Type().Field(i).Name
+		if unstored[name] {
+			if !restored.Field(i).IsZero() {
+				t.Errorf("Expected Options.%s not to be stored, got %v", name, restored.Field(i))
+			}
+			continue
+		}
+
+		if !sameOptionField(original.Field(i), restored.Field(i)) {
+			t.Errorf("Expected Options.%s to survive a round trip, got %v instead of %v", name, reflect.Indirect(restored.Field(i)), reflect.Indirect(original.Field(i)))
+		}
+	}
+}
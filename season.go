@@ -0,0 +1,97 @@
+package openskill
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/samber/lo"
+)
+
+// SeasonTarget is the skill that SeasonReset pulls every rating toward.
+type SeasonTarget int
+
+const (
+	// TowardDefault pulls the ratings toward the skill of a new player, Options.AveragePlayerSkill.
+	TowardDefault SeasonTarget = iota
+	// TowardPopulation pulls the ratings toward the average skill of the ratings being reset, so
+	// the reset doesn't move the population as a whole.
+	TowardPopulation
+)
+
+// SeasonResetParams holds how SeasonReset changes the ratings at the start of a season.
+type SeasonResetParams struct {
+	// Pull is the fraction of the distance between each skill and the target that is removed,
+	// from 0, which keeps the skills as they are, to 1, which sets every skill to the target.
+	Pull float64
+
+	// Target is the skill the ratings are pulled toward. It defaults to TowardDefault.
+	Target SeasonTarget
+
+	// SigmaInflation is added to the uncertainty of every rating, in the same way as Options.Tau,
+	// so the ratings can move faster during the first matches of the season.
+	SigmaInflation float64
+
+	// SigmaFloor is the smallest uncertainty a rating can have after the reset. When 0, there is no floor.
+	SigmaFloor float64
+
+	// SigmaCeiling is the largest uncertainty a rating can have after the reset. When 0, it defaults
+	// to Options.SkillUncertaintyDegree, the uncertainty of a new player.
+	SigmaCeiling float64
+}
+
+// SeasonReset softly resets a set of ratings at a season boundary: every skill is pulled toward the
+// target by the given fraction, and every uncertainty is inflated and then kept between the floor
+// and the ceiling. It returns new ratings, in the same order, without changing the given ones. It
+// returns an error wrapping ErrInvalidSeasonReset when the parameters are out of range, or
+// ErrNilRating, ErrInvalidMu or ErrInvalidSigma when one of the ratings is not usable.
+func SeasonReset(ratings []*Rating, params SeasonResetParams, options *Options) ([]*Rating, error) {
+	ceiling := params.SigmaCeiling
+	if ceiling == 0 {
+		ceiling = sigma(options)
+	}
+
+	if !(params.Pull >= 0 && params.Pull <= 1) {
+		return nil, fmt.Errorf("%w: Pull must be in the [0, 1] interval", ErrInvalidSeasonReset)
+	}
+	if !(isFinite(params.SigmaInflation) && params.SigmaInflation >= 0) {
+		return nil, fmt.Errorf("%w: SigmaInflation must be non-negative and finite", ErrInvalidSeasonReset)
+	}
+	if !(isFinite(params.SigmaFloor) && params.SigmaFloor >= 0) {
+		return nil, fmt.Errorf("%w: SigmaFloor must be non-negative and finite", ErrInvalidSeasonReset)
+	}
+	if !(isFinite(ceiling) && ceiling > 0 && ceiling >= params.SigmaFloor) {
+		return nil, fmt.Errorf("%w: SigmaCeiling must be finite and not smaller than SigmaFloor", ErrInvalidSeasonReset)
+	}
+	if params.Target != TowardDefault && params.Target != TowardPopulation {
+		return nil, fmt.Errorf("%w: unknown target %d", ErrInvalidSeasonReset, params.Target)
+	}
+
+	for i, rating := range ratings {
+		if rating == nil {
+			return nil, fmt.Errorf("%w: player %d", ErrNilRating, i)
+		}
+		if !isFinite(rating.AveragePlayerSkill) {
+			return nil, fmt.Errorf("%w: player %d", ErrInvalidMu, i)
+		}
+		if !(isFinite(rating.SkillUncertaintyDegree) && rating.SkillUncertaintyDegree > 0) {
+			return nil, fmt.Errorf("%w: player %d", ErrInvalidSigma, i)
+		}
+	}
+
+	target := mu(options)
+	if params.Target == TowardPopulation && len(ratings) > 0 {
+		target = lo.SumBy(ratings, func(rating *Rating) float64 {
+			return rating.AveragePlayerSkill
+		}) / float64(len(ratings))
+	}
+
+	return lo.Map(ratings, func(rating *Rating, _ int) *Rating {
+		uncertainty := math.Sqrt(rating.SkillUncertaintyDegree*rating.SkillUncertaintyDegree + params.SigmaInflation*params.SigmaInflation)
+
+		reset := *rating
+		reset.AveragePlayerSkill += params.Pull * (target - rating.AveragePlayerSkill)
+		reset.SkillUncertaintyDegree = math.Min(math.Max(uncertainty, params.SigmaFloor), ceiling)
+
+		return &reset
+	}), nil
+}
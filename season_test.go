@@ -0,0 +1,69 @@
+package openskill_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+func TestSeasonReset(t *testing.T) {
+	ratings := []*openskill.Rating{
+		{AveragePlayerSkill: 35, SkillUncertaintyDegree: 2},
+		{AveragePlayerSkill: 15, SkillUncertaintyDegree: 3},
+		{AveragePlayerSkill: 40, SkillUncertaintyDegree: 8.2},
+	}
+
+	reset, err := openskill.SeasonReset(ratings, openskill.SeasonResetParams{Pull: 0.5, SigmaInflation: 1.5}, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []openskill.Rating{
+		{AveragePlayerSkill: 30, SkillUncertaintyDegree: 2.5},
+		{AveragePlayerSkill: 20, SkillUncertaintyDegree: math.Sqrt(9 + 2.25)},
+		// the uncertainty is capped at the one of a new player.
+		{AveragePlayerSkill: 32.5, SkillUncertaintyDegree: 25.0 / 3},
+	}
+	for i, rating := range reset {
+		if math.Abs(rating.AveragePlayerSkill-expected[i].AveragePlayerSkill) > 1e-12 || math.Abs(rating.SkillUncertaintyDegree-expected[i].SkillUncertaintyDegree) > 1e-12 {
+			t.Errorf("Expected player %d to be reset to %v, got %v", i, expected[i], *rating)
+		}
+	}
+
+	if ratings[0].AveragePlayerSkill != 35 || ratings[0].SkillUncertaintyDegree != 2 {
+		t.Errorf("Expected the ratings to be untouched, got %v", *ratings[0])
+	}
+
+	// the population mean is 30, and the floor lifts the most certain ratings.
+	population, err := openskill.SeasonReset(ratings, openskill.SeasonResetParams{Pull: 1, Target: openskill.TowardPopulation, SigmaFloor: 4, SigmaCeiling: 6}, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for i, rating := range population {
+		if math.Abs(rating.AveragePlayerSkill-30) > 1e-12 {
+			t.Errorf("Expected player %d to be pulled to 30, got %v", i, rating.AveragePlayerSkill)
+		}
+	}
+	if population[0].SkillUncertaintyDegree != 4 || population[2].SkillUncertaintyDegree != 6 {
+		t.Errorf("Expected the uncertainties to be kept between 4 and 6, got %v and %v", population[0].SkillUncertaintyDegree, population[2].SkillUncertaintyDegree)
+	}
+
+	for _, params := range []openskill.SeasonResetParams{
+		{Pull: -0.1},
+		{Pull: 1.1},
+		{Pull: math.NaN()},
+		{SigmaInflation: -1},
+		{SigmaFloor: 5, SigmaCeiling: 4},
+		{Target: openskill.SeasonTarget(7)},
+	} {
+		if _, err := openskill.SeasonReset(ratings, params, nil); !errors.Is(err, openskill.ErrInvalidSeasonReset) {
+			t.Errorf("Expected ErrInvalidSeasonReset for %+v, got %v", params, err)
+		}
+	}
+
+	if _, err := openskill.SeasonReset([]*openskill.Rating{nil}, openskill.SeasonResetParams{}, nil); !errors.Is(err, openskill.ErrNilRating) {
+		t.Errorf("Expected ErrNilRating, got %v", err)
+	}
+}
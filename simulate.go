@@ -0,0 +1,176 @@
+package openskill
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// Simulation holds the outcome of many simulated matches between the same teams.
+type Simulation struct {
+	// Matches is the amount of matches simulated.
+	Matches int
+
+	// Placements is a histogram of the positions each team finished in, where Placements[i][k]
+	// is how many times the team i finished in the position k, starting at zero for the first place.
+	Placements [][]int
+}
+
+// Probabilities returns the share of the matches each team finished in each position.
+func (s *Simulation) Probabilities() [][]float64 {
+	return lo.Map(s.Placements, func(item []int, index int) []float64 {
+		return lo.Map(item, func(count int, position int) float64 {
+			if s.Matches == 0 {
+				return 0
+			}
+			return float64(count) / float64(s.Matches)
+		})
+	})
+}
+
+// gaussianModels are the models that assume a normal distribution of the performances.
+var gaussianModels = []Model{ThurstoneMostellerFull, ThurstoneMostellerPart, TrueSkill}
+
+// isGaussianModel tells if the model configured in options assumes a normal distribution of
+// the performances.
+func isGaussianModel(options *Options) bool {
+	if options == nil || options.Model == nil {
+		return false
+	}
+
+	return isModel(*options.Model, gaussianModels)
+}
+
+// isModel tells if a model is one of the given models. Functions cannot be compared in Go, so
+// their entry points are compared instead.
+func isModel(model Model, models []Model) bool {
+	pointer := reflect.ValueOf(model).Pointer()
+
+	return lo.ContainsBy(models, func(item Model) bool {
+		return reflect.ValueOf(item).Pointer() == pointer
+	})
+}
+
+// samplePerformance returns a function that draws the performance of a player, given their skill
+// and the standard deviation of their performance.
+func samplePerformance(options *Options, rng *rand.Rand) func(mu, deviation float64) float64 {
+	if isGaussianModel(options) {
+		return func(mu, deviation float64) float64 {
+			return mu + deviation*rng.NormFloat64()
+		}
+	}
+
+	return func(mu, deviation float64) float64 {
+		u := rng.Float64()
+		for u == 0 {
+			u = rng.Float64()
+		}
+
+		// a logistic distribution with scale s has a standard deviation of s * pi / sqrt(3).
+		return mu + deviation*math.Sqrt(3)/math.Pi*math.Log(u/(1-u))
+	}
+}
+
+// simulateMatch draws the performance of every team and returns the position each team finished in.
+func simulateMatch(teams []Team, options *Options, sample func(mu, deviation float64) float64) []int {
+	betaSq := betaSq(options)
+
+	performances := lo.Map(teams, func(item Team, index int) float64 {
+		weights := teamWeights(options, index, len(item))
+
+		return lo.Sum(lo.Map([]*Rating(item), func(rating *Rating, localIndex int) float64 {
+			return weights[localIndex] * sample(rating.AveragePlayerSkill, math.Sqrt(rating.SkillUncertaintyDegree*rating.SkillUncertaintyDegree+betaSq))
+		}))
+	})
+
+	order := lo.Range(len(teams))
+	sort.SliceStable(order, func(i, j int) bool {
+		return performances[order[i]] > performances[order[j]]
+	})
+
+	positions := make([]int, len(teams))
+	for position, team := range order {
+		positions[team] = position
+	}
+
+	return positions
+}
+
+func newSimulation(size int) *Simulation {
+	placements := make([][]int, size)
+	for i := range placements {
+		placements[i] = make([]int, size)
+	}
+
+	return &Simulation{Placements: placements}
+}
+
+// Simulate plays n matches between the teams, drawing the performance of each player from their
+// rating, and counts the positions each team finished in. The performance of a player is normally
+// distributed for the Thurstone-Mosteller and TrueSkill models, and logistically distributed for
+// every other model, with the skill of the player as its mean and sqrt(sigma^2 + beta^2) as
+// its standard deviation. The performance of a team is the weighted sum of its players. When rng
+// is nil, a generator with a fixed seed is used, so the simulation is reproducible.
+func Simulate(teams []Team, n int, rng *rand.Rand, options *Options) *Simulation {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(1))
+	}
+
+	sample := samplePerformance(options, rng)
+	simulation := newSimulation(len(teams))
+
+	for match := 0; match < n; match++ {
+		for team, position := range simulateMatch(teams, options, sample) {
+			simulation.Placements[team][position]++
+		}
+		simulation.Matches++
+	}
+
+	return simulation
+}
+
+// SimulateSeason plays n matches between the teams like Simulate does, drawing the outcomes from
+// the truth teams, which hold the true skill of each player, and feeding every outcome through
+// Rate to update the ratings teams, which must have the same shape. It returns the placements of
+// the season together with the final ratings, which makes it possible to check how fast and how
+// well the ratings converge to the true skills. When truth is nil, the outcomes are drawn from the
+// ratings as they evolve during the season instead. The ratings are not mutated.
+func SimulateSeason(truth []Team, ratings []Team, n int, rng *rand.Rand, options *Options) (*Simulation, []Team) {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(1))
+	}
+
+	var rateOptions Options
+	if options != nil {
+		rateOptions = *options
+	}
+
+	sample := samplePerformance(options, rng)
+	simulation := newSimulation(len(ratings))
+
+	current := lo.Map(ratings, func(item Team, index int) Team {
+		return copyTeam(item)
+	})
+
+	for match := 0; match < n; match++ {
+		source := lo.Ternary(truth == nil, current, truth)
+		positions := simulateMatch(source, options, sample)
+
+		for team, position := range positions {
+			simulation.Placements[team][position]++
+		}
+		simulation.Matches++
+
+		rateOptions.Rankings = lo.Map(positions, func(item int, index int) int64 {
+			return int64(item)
+		})
+		rateOptions.Scores, rateOptions.FloatScores, rateOptions.FloatRankings = nil, nil, nil
+
+		current = Rate(current, rateOptions)
+	}
+
+	return simulation, current
+}
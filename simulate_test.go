@@ -0,0 +1,52 @@
+package openskill_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+func TestSimulate(t *testing.T) {
+	teams := []openskill.Team{
+		openskill.NewTeam(openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: 30, SkillUncertaintyDegree: 1}, nil)),
+		openskill.NewTeam(openskill.NewRating(&openskill.NewRatingParams{AveragePlayerSkill: 25, SkillUncertaintyDegree: 1}, nil)),
+	}
+
+	for name, model := range map[string]openskill.Model{"PlackettLuce": openskill.PlackettLuce, "ThurstoneMostellerFull": openskill.ThurstoneMostellerFull} {
+		model := model
+		options := &openskill.Options{Model: &model}
+
+		simulation := openskill.Simulate(teams, 20000, rand.New(rand.NewSource(42)), options)
+		if simulation.Matches != 20000 || simulation.Placements[0][0]+simulation.Placements[0][1] != 20000 {
+			t.Fatalf("%s: expected 20000 placements, got %v", name, simulation.Placements)
+		}
+
+		// both distributions have the same deviation, so the win probability is close to a normal one.
+		expected := 0.5 * math.Erfc(-5/math.Sqrt(2*(1+math.Pow(25.0/6, 2)))/math.Sqrt2)
+		if probability := simulation.Probabilities()[0][0]; math.Abs(probability-expected) > 0.02 {
+			t.Errorf("%s: expected the stronger team to win about %f of the matches, got %f", name, expected, probability)
+		}
+	}
+
+	truth := []openskill.Team{
+		openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 40, SkillUncertaintyDegree: 0.1}),
+		openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 10, SkillUncertaintyDegree: 0.1}),
+	}
+	ratings := []openskill.Team{
+		openskill.NewTeam(openskill.NewRating(nil, nil)),
+		openskill.NewTeam(openskill.NewRating(nil, nil)),
+	}
+
+	simulation, season := openskill.SimulateSeason(truth, ratings, 50, rand.New(rand.NewSource(42)), nil)
+	if simulation.Matches != 50 {
+		t.Errorf("Expected 50 matches, got %d", simulation.Matches)
+	}
+	if season[0][0].AveragePlayerSkill <= season[1][0].AveragePlayerSkill || season[0][0].SkillUncertaintyDegree >= ratings[0][0].SkillUncertaintyDegree {
+		t.Errorf("Expected the ratings to converge towards the truth, got %v and %v", *season[0][0], *season[1][0])
+	}
+	if *ratings[0][0] != *openskill.NewRating(nil, nil) {
+		t.Errorf("Expected the ratings to be untouched, got %v", *ratings[0][0])
+	}
+}
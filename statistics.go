@@ -40,6 +40,8 @@ func v(x, t float64) float64 {
 	return pdf(xt) / denom
 }
 
+// vt is the additive correction of the mean of a draw, which has the opposite sign of x, so a draw
+// pulls the two sides together.
 func vt(x, t float64) float64 {
 	xx := math.Abs(x)
 	b := cdf((t - xx)) - cdf((-t - xx))
@@ -52,7 +54,7 @@ func vt(x, t float64) float64 {
 	}
 
 	a := pdf((-t - xx)) - pdf((t - xx))
-	return lo.Ternary(x < 0, a, -a) / b
+	return lo.Ternary(x < 0, -a, a) / b
 }
 
 func w(x, t float64) float64 {
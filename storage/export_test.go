@@ -0,0 +1,36 @@
+package storage
+
+import "errors"
+
+// ErrInjected is returned by the writes made to fail by FailWrites.
+var ErrInjected = errors.New("injected failure")
+
+// failingLog is a log that writes only part of what it is given before failing, like a disk that
+// runs out of space, and can also fail to truncate.
+type failingLog struct {
+	logWriter
+	written       int
+	failTruncates bool
+}
+
+func (l *failingLog) Write(data []byte) (int, error) {
+	written, _ := l.logWriter.Write(data[:l.written])
+	return written, ErrInjected
+}
+
+func (l *failingLog) Truncate(size int64) error {
+	if l.failTruncates {
+		return ErrInjected
+	}
+	return l.logWriter.Truncate(size)
+}
+
+// FailWrites makes every later write to the log of the store fail after writing the given number
+// of bytes, and truncates of the log fail too when failTruncates is set. It returns a function
+// that restores the log.
+func FailWrites[ID comparable](store *FileStore[ID], written int, failTruncates bool) func() {
+	log := store.log
+	store.log = &failingLog{logWriter: log, written: written, failTruncates: failTruncates}
+
+	return func() { store.log = log }
+}
@@ -0,0 +1,364 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+const (
+	snapshotFile = "snapshot.json"
+	logFile      = "log.jsonl"
+)
+
+// fileEntry is a record as written to the files of a FileStore.
+type fileEntry[ID comparable] struct {
+	ID         ID               `json:"id"`
+	Rating     openskill.Rating `json:"rating"`
+	Version    uint64           `json:"version"`
+	LastPlayed time.Time        `json:"lastPlayed"`
+}
+
+// fileBatch is a line of the log of a FileStore, holding every record written by a single batch,
+// so a batch is either fully on the log or not on it at all.
+type fileBatch[ID comparable] struct {
+	Records []fileEntry[ID] `json:"records"`
+}
+
+// logWriter is the log of a FileStore, an *os.File outside of the tests.
+type logWriter interface {
+	io.WriteSeeker
+	Sync() error
+	Truncate(size int64) error
+	Close() error
+}
+
+// FileStore is a Store that keeps the ratings in memory, backed by files on a directory: an
+// append-only log with every write, and a snapshot of every rating, which is rewritten by Compact.
+// Every write is synced to disk before it is acknowledged. The identifiers must be encodable as
+// JSON. It is safe for concurrent use, but a directory must not be opened by more than one store.
+type FileStore[ID comparable] struct {
+	mutex   sync.RWMutex
+	dir     string
+	log     logWriter
+	size    int64
+	failure error
+	records map[ID]Record
+}
+
+// OpenFileStore opens the store on a directory, creating it if needed, and loads the snapshot and
+// the log into memory. A batch that was only partially written to the end of the log, which can
+// happen after a crash, is discarded, but any other unreadable data is reported as ErrCorrupted.
+func OpenFileStore[ID comparable](dir string) (*FileStore[ID], error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	store := &FileStore[ID]{dir: dir, records: make(map[ID]Record)}
+
+	if err := store.loadSnapshot(); err != nil {
+		return nil, err
+	}
+
+	if err := store.loadLog(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *FileStore[ID]) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(s.dir, snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var entries []fileEntry[ID]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("%w: snapshot: %v", ErrCorrupted, err)
+	}
+
+	for _, entry := range entries {
+		s.records[entry.ID] = Record{Rating: entry.Rating, Version: entry.Version, LastPlayed: entry.LastPlayed}
+	}
+
+	return nil
+}
+
+func (s *FileStore[ID]) loadLog() error {
+	log, err := os.OpenFile(filepath.Join(s.dir, logFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(log)
+	var valid int64
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		// a line without its newline can only be a batch torn by a crash.
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Close()
+			return err
+		}
+
+		var batch fileBatch[ID]
+		if err := json.Unmarshal(bytes.TrimSpace(line), &batch); err != nil {
+			// only the last line can be torn, anything before it was acknowledged.
+			if _, err := reader.Peek(1); errors.Is(err, io.EOF) {
+				break
+			}
+			log.Close()
+			return fmt.Errorf("%w: log at offset %d", ErrCorrupted, valid)
+		}
+
+		for _, entry := range batch.Records {
+			s.records[entry.ID] = Record{Rating: entry.Rating, Version: entry.Version, LastPlayed: entry.LastPlayed}
+		}
+
+		valid += int64(len(line))
+	}
+
+	// drop whatever follows the last complete batch.
+	if err := log.Truncate(valid); err != nil {
+		log.Close()
+		return err
+	}
+	if _, err := log.Seek(valid, io.SeekStart); err != nil {
+		log.Close()
+		return err
+	}
+
+	s.log = log
+	s.size = valid
+
+	return nil
+}
+
+// Get returns the rating of a player, or ErrNotFound.
+func (s *FileStore[ID]) Get(id ID) (Record, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.log == nil {
+		return Record{}, ErrClosed
+	}
+
+	record, ok := s.records[id]
+	if !ok {
+		return Record{}, fmt.Errorf("%w: %v", ErrNotFound, id)
+	}
+
+	return record, nil
+}
+
+// Put writes the rating of a player, returning its new record, or ErrVersionConflict.
+func (s *FileStore[ID]) Put(id ID, rating openskill.Rating, version uint64) (Record, error) {
+	records, err := s.BatchPut([]Update[ID]{{ID: id, Rating: rating, Version: version}})
+	if err != nil {
+		return Record{}, err
+	}
+
+	return records[id], nil
+}
+
+// BatchGet returns the ratings of many players. Players without a stored rating are left out.
+func (s *FileStore[ID]) BatchGet(ids []ID) (map[ID]Record, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.log == nil {
+		return nil, ErrClosed
+	}
+
+	result := make(map[ID]Record)
+
+	for _, id := range ids {
+		if record, ok := s.records[id]; ok {
+			result[id] = record
+		}
+	}
+
+	return result, nil
+}
+
+// BatchPut writes the ratings of many players atomically, returning the new records, or
+// ErrVersionConflict. The batch is appended to the log as a single line and synced to disk
+// before the ratings are visible to readers. When the write fails, the log is truncated back to
+// where it was, and if even that fails, the store refuses every later write.
+func (s *FileStore[ID]) BatchPut(updates []Update[ID]) (map[ID]Record, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.log == nil {
+		return nil, ErrClosed
+	}
+	if s.failure != nil {
+		return nil, fmt.Errorf("storage: store is unusable after a failed write: %w", s.failure)
+	}
+
+	// the versions are checked against a copy, so nothing changes if the write fails.
+	staged := make(map[ID]Record)
+	for _, update := range updates {
+		if record, ok := s.records[update.ID]; ok {
+			staged[update.ID] = record
+		}
+	}
+
+	result, err := apply(staged, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := fileBatch[ID]{}
+	for _, update := range updates {
+		record := result[update.ID]
+		batch.Records = append(batch.Records, fileEntry[ID]{ID: update.ID, Rating: record.Rating, Version: record.Version, LastPlayed: record.LastPlayed})
+	}
+
+	line, err := json.Marshal(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	line = append(line, '\n')
+
+	if _, err := s.log.Write(line); err != nil {
+		return nil, s.rollback(err)
+	}
+	if err := s.log.Sync(); err != nil {
+		return nil, s.rollback(err)
+	}
+
+	s.size += int64(len(line))
+
+	for id, record := range result {
+		s.records[id] = record
+	}
+
+	return result, nil
+}
+
+// rollback drops whatever a failed write left on the log, so the next batch does not follow a
+// torn line, and returns the error of the write.
+func (s *FileStore[ID]) rollback(err error) error {
+	if truncateErr := s.log.Truncate(s.size); truncateErr != nil {
+		s.failure = truncateErr
+		return err
+	}
+	if _, seekErr := s.log.Seek(s.size, io.SeekStart); seekErr != nil {
+		s.failure = seekErr
+		return err
+	}
+	if syncErr := s.log.Sync(); syncErr != nil {
+		s.failure = syncErr
+	}
+
+	return err
+}
+
+// Compact writes every rating to a new snapshot and empties the log. The snapshot is written to
+// a temporary file that replaces the previous one, so a crash never leaves a partial snapshot. A
+// store that refuses writes after a failed one accepts them again once compacted.
+func (s *FileStore[ID]) Compact() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.log == nil {
+		return ErrClosed
+	}
+
+	entries := make([]fileEntry[ID], 0, len(s.records))
+	for id, record := range s.records {
+		entries = append(entries, fileEntry[ID]{ID: id, Rating: record.Rating, Version: record.Version, LastPlayed: record.LastPlayed})
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	temporary, err := os.CreateTemp(s.dir, snapshotFile+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temporary.Name())
+
+	if _, err := temporary.Write(data); err != nil {
+		temporary.Close()
+		return err
+	}
+	if err := temporary.Sync(); err != nil {
+		temporary.Close()
+		return err
+	}
+	if err := temporary.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(temporary.Name(), filepath.Join(s.dir, snapshotFile)); err != nil {
+		return err
+	}
+
+	// the rename must be on disk before the log is emptied, or a crash could lose both.
+	if err := syncDir(s.dir); err != nil {
+		return err
+	}
+
+	if err := s.log.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := s.log.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	s.size = 0
+	// the log no longer holds what a failed write left behind.
+	s.failure = nil
+
+	return nil
+}
+
+// syncDir flushes the entries of a directory to disk.
+func syncDir(dir string) error {
+	directory, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	if err := directory.Sync(); err != nil {
+		directory.Close()
+		return err
+	}
+
+	return directory.Close()
+}
+
+// Close closes the log. The store cannot be read from or written to after it is closed.
+func (s *FileStore[ID]) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.log == nil {
+		return nil
+	}
+
+	err := s.log.Close()
+	s.log = nil
+
+	return err
+}
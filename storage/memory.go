@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+// MemoryStore is a Store that keeps the ratings in memory. It is safe for concurrent use.
+type MemoryStore[ID comparable] struct {
+	mutex   sync.RWMutex
+	records map[ID]Record
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore[ID comparable]() *MemoryStore[ID] {
+	return &MemoryStore[ID]{records: make(map[ID]Record)}
+}
+
+// Get returns the rating of a player, or ErrNotFound.
+func (s *MemoryStore[ID]) Get(id ID) (Record, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	record, ok := s.records[id]
+	if !ok {
+		return Record{}, fmt.Errorf("%w: %v", ErrNotFound, id)
+	}
+
+	return record, nil
+}
+
+// Put writes the rating of a player, returning its new record, or ErrVersionConflict.
+func (s *MemoryStore[ID]) Put(id ID, rating openskill.Rating, version uint64) (Record, error) {
+	records, err := s.BatchPut([]Update[ID]{{ID: id, Rating: rating, Version: version}})
+	if err != nil {
+		return Record{}, err
+	}
+
+	return records[id], nil
+}
+
+// BatchGet returns the ratings of many players. Players without a stored rating are left out.
+func (s *MemoryStore[ID]) BatchGet(ids []ID) (map[ID]Record, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := make(map[ID]Record)
+
+	for _, id := range ids {
+		if record, ok := s.records[id]; ok {
+			result[id] = record
+		}
+	}
+
+	return result, nil
+}
+
+// BatchPut writes the ratings of many players atomically, returning the new records, or ErrVersionConflict.
+func (s *MemoryStore[ID]) BatchPut(updates []Update[ID]) (map[ID]Record, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return apply(s.records, updates)
+}
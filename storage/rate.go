@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+// RateAndStore rates a game played now between teams of stored players. See RateAndStoreAt.
+func RateAndStore[ID comparable](store Store[ID], teams [][]ID, options openskill.Options) (map[ID]openskill.Rating, error) {
+	return RateAndStoreAt(store, teams, options, time.Now())
+}
+
+// RateAndStoreAt rates a game played at the given time between teams of stored players and writes
+// the new ratings back in a single batch. Players without a stored rating start with the default
+// rating of the options, and the stored ratings decay with openskill.DecayRating for the time since
+// the player last played. If any of the players was updated by someone else while the game was
+// being rated, nothing is written and ErrVersionConflict is returned, so the caller can simply try
+// again.
+func RateAndStoreAt[ID comparable](store Store[ID], teams [][]ID, options openskill.Options, at time.Time) (map[ID]openskill.Rating, error) {
+	ids := make([]ID, 0)
+	for _, team := range teams {
+		ids = append(ids, team...)
+	}
+
+	records, err := store.BatchGet(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rosters := make([]openskill.Roster[ID], len(teams))
+	for i, team := range teams {
+		roster := make(openskill.Roster[ID], len(team))
+
+		for j, id := range team {
+			record, ok := records[id]
+			switch {
+			case !ok:
+				record.Rating = *openskill.NewRating(nil, &options)
+			case !record.LastPlayed.IsZero():
+				record.Rating = openskill.DecayRating(record.Rating, at.Sub(record.LastPlayed), &options)
+			}
+
+			roster[j] = openskill.Player[ID]{ID: id, Rating: record.Rating}
+		}
+
+		rosters[i] = roster
+	}
+
+	ratings, err := openskill.RateRosters(rosters, options)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make([]Update[ID], 0, len(ids))
+	for _, id := range ids {
+		updates = append(updates, Update[ID]{ID: id, Rating: ratings[id], Version: records[id].Version, LastPlayed: at})
+	}
+
+	if _, err := store.BatchPut(updates); err != nil {
+		return nil, err
+	}
+
+	return ratings, nil
+}
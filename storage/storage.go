@@ -0,0 +1,91 @@
+// Package storage defines how ratings are persisted, with optimistic versioning so concurrent
+// writers can never silently overwrite each other, plus an in-memory and a file-backed store.
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+var (
+	// ErrNotFound is returned when there is no rating stored for a player.
+	ErrNotFound = errors.New("storage: rating not found")
+
+	// ErrVersionConflict is returned when a rating is written with a version that is not the
+	// current version of the stored rating, meaning someone else updated it in the meantime.
+	ErrVersionConflict = errors.New("storage: version conflict")
+
+	// ErrClosed is returned when a store is used after being closed.
+	ErrClosed = errors.New("storage: store is closed")
+
+	// ErrCorrupted is returned when the files of a store hold data that cannot be read back, other
+	// than a batch partially written at the end of the log.
+	ErrCorrupted = errors.New("storage: corrupted data")
+)
+
+// Record is a stored rating, along with its version and when the player last played, if known.
+// The version starts at 1 and grows by one on every write.
+type Record struct {
+	Rating     openskill.Rating
+	Version    uint64
+	LastPlayed time.Time
+}
+
+// Update is a write of a rating, which only succeeds if Version is the current version of the
+// stored rating, or 0 when the player has no rating stored yet. When LastPlayed is zero, the
+// stored time of the last match of the player is kept.
+type Update[ID comparable] struct {
+	ID         ID
+	Rating     openskill.Rating
+	Version    uint64
+	LastPlayed time.Time
+}
+
+// Store persists the ratings of players, keyed by their identifiers.
+type Store[ID comparable] interface {
+	// Get returns the rating of a player, or ErrNotFound.
+	Get(id ID) (Record, error)
+
+	// Put writes the rating of a player, returning its new record, or ErrVersionConflict.
+	Put(id ID, rating openskill.Rating, version uint64) (Record, error)
+
+	// BatchGet returns the ratings of many players. Players without a stored rating are left out.
+	BatchGet(ids []ID) (map[ID]Record, error)
+
+	// BatchPut writes the ratings of many players atomically, so either every update is applied
+	// or none is. It returns the new records, or ErrVersionConflict.
+	BatchPut(updates []Update[ID]) (map[ID]Record, error)
+}
+
+// apply checks the versions of the updates against the records and, when all of them match,
+// applies them, returning the new records.
+func apply[ID comparable](records map[ID]Record, updates []Update[ID]) (map[ID]Record, error) {
+	result := make(map[ID]Record)
+
+	for _, update := range updates {
+		if _, ok := result[update.ID]; ok {
+			return nil, fmt.Errorf("%w: %v is updated more than once", ErrVersionConflict, update.ID)
+		}
+
+		current := records[update.ID]
+		if current.Version != update.Version {
+			return nil, fmt.Errorf("%w: %v is at version %d, not %d", ErrVersionConflict, update.ID, current.Version, update.Version)
+		}
+
+		lastPlayed := update.LastPlayed
+		if lastPlayed.IsZero() {
+			lastPlayed = current.LastPlayed
+		}
+
+		result[update.ID] = Record{Rating: update.Rating, Version: update.Version + 1, LastPlayed: lastPlayed}
+	}
+
+	for id, record := range result {
+		records[id] = record
+	}
+
+	return result, nil
+}
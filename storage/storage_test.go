@@ -0,0 +1,335 @@
+package storage_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eullerpereira94/openskill"
+	"github.com/eullerpereira94/openskill/storage"
+)
+
+func testStore(t *testing.T, store storage.Store[string]) {
+	t.Helper()
+
+	if _, err := store.Get("a"); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+
+	record, err := store.Put("a", openskill.Rating{AveragePlayerSkill: 30, SkillUncertaintyDegree: 5}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Version != 1 {
+		t.Fatalf("Expected version 1, got %d", record.Version)
+	}
+
+	if _, err := store.Put("a", openskill.Rating{AveragePlayerSkill: 20, SkillUncertaintyDegree: 5}, 0); !errors.Is(err, storage.ErrVersionConflict) {
+		t.Fatalf("Expected ErrVersionConflict, got %v", err)
+	}
+
+	// a conflicting update must not let any other update of the batch through.
+	_, err = store.BatchPut([]storage.Update[string]{
+		{ID: "b", Rating: openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: 8}, Version: 0},
+		{ID: "a", Rating: openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: 8}, Version: 5},
+	})
+	if !errors.Is(err, storage.ErrVersionConflict) {
+		t.Fatalf("Expected ErrVersionConflict, got %v", err)
+	}
+	if _, err := store.Get("b"); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("Expected the batch to be discarded, got %v", err)
+	}
+
+	records, err := store.BatchPut([]storage.Update[string]{
+		{ID: "a", Rating: openskill.Rating{AveragePlayerSkill: 31, SkillUncertaintyDegree: 4}, Version: 1},
+		{ID: "b", Rating: openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: 8}, Version: 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records["a"].Version != 2 || records["b"].Version != 1 {
+		t.Fatalf("Expected a at version 2 and b at version 1, got %v", records)
+	}
+
+	records, err = store.BatchGet([]string{"a", "b", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records["a"].Rating.AveragePlayerSkill != 31 {
+		t.Fatalf("Expected a and b with a rated 31, got %v", records)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, storage.NewMemoryStore[string]())
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := storage.OpenFileStore[string](dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testStore(t, store)
+
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Put("c", openskill.Rating{}, 0); !errors.Is(err, storage.ErrClosed) {
+		t.Fatalf("Expected ErrClosed, got %v", err)
+	}
+	if _, err := store.Get("a"); !errors.Is(err, storage.ErrClosed) {
+		t.Fatalf("Expected ErrClosed, got %v", err)
+	}
+	if _, err := store.BatchGet([]string{"a"}); !errors.Is(err, storage.ErrClosed) {
+		t.Fatalf("Expected ErrClosed, got %v", err)
+	}
+
+	check := func(store *storage.FileStore[string]) {
+		t.Helper()
+
+		record, err := store.Get("a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if record.Version != 2 || record.Rating.AveragePlayerSkill != 31 {
+			t.Fatalf("Expected a at version 2 rated 31, got %v", record)
+		}
+	}
+
+	store, err = storage.OpenFileStore[string](dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(store)
+
+	if err := store.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Put("c", openskill.Rating{AveragePlayerSkill: 1, SkillUncertaintyDegree: 1}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate a crash in the middle of writing a batch.
+	log, err := os.OpenFile(filepath.Join(dir, "log.jsonl"), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := log.WriteString(`{"records":[{"id":"a","rat`); err != nil {
+		t.Fatal(err)
+	}
+	log.Close()
+
+	store, err = storage.OpenFileStore[string](dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	check(store)
+
+	if _, err := store.Get("c"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Put("a", openskill.Rating{AveragePlayerSkill: 32, SkillUncertaintyDegree: 4}, 2); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileStoreFailedWrite(t *testing.T) {
+	dir := t.TempDir()
+	rating := openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: 8}
+
+	store, err := storage.OpenFileStore[string](dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Put("a", rating, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// a write that fails halfway is rolled back, so the batches after it survive a reopen.
+	restore := storage.FailWrites(store, 10, false)
+	if _, err := store.Put("b", rating, 0); !errors.Is(err, storage.ErrInjected) {
+		t.Fatalf("Expected ErrInjected, got %v", err)
+	}
+	restore()
+
+	if _, err := store.Get("b"); !errors.Is(err, storage.ErrNotFound) {
+		t.Fatalf("Expected the failed write not to be visible, got %v", err)
+	}
+	if _, err := store.Put("c", rating, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = storage.OpenFileStore[string](dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"a", "c"} {
+		if _, err := store.Get(id); err != nil {
+			t.Fatalf("Expected %s to survive the failed write, got %v", id, err)
+		}
+	}
+
+	// when the failed write cannot be rolled back, the store refuses writes until compacted.
+	restore = storage.FailWrites(store, 10, true)
+	if _, err := store.Put("b", rating, 0); !errors.Is(err, storage.ErrInjected) {
+		t.Fatalf("Expected ErrInjected, got %v", err)
+	}
+	restore()
+
+	if _, err := store.Put("b", rating, 0); !errors.Is(err, storage.ErrInjected) {
+		t.Fatalf("Expected the store to refuse writes, got %v", err)
+	}
+	if err := store.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Put("b", rating, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = storage.OpenFileStore[string](dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	for _, id := range []string{"a", "b", "c"} {
+		if _, err := store.Get(id); err != nil {
+			t.Fatalf("Expected %s to be stored, got %v", id, err)
+		}
+	}
+}
+
+func TestFileStoreCorruption(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := storage.OpenFileStore[string](dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Put("a", openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: 8}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// an unreadable line followed by a batch is not a torn write, but corruption.
+	path := filepath.Join(dir, "log.jsonl")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, append([]byte("garbage\n"), data...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := storage.OpenFileStore[string](dir); !errors.Is(err, storage.ErrCorrupted) {
+		t.Fatalf("Expected ErrCorrupted, got %v", err)
+	}
+}
+
+func TestRateAndStore(t *testing.T) {
+	store := storage.NewMemoryStore[string]()
+	options := openskill.Options{}
+
+	ratings, err := storage.RateAndStore[string](store, [][]string{{"a", "b"}, {"c", "d"}}, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"a", "b", "c", "d"} {
+		record, err := store.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if record.Version != 1 || record.Rating != ratings[id] {
+			t.Fatalf("Expected %s at version 1 rated %v, got %v", id, ratings[id], record)
+		}
+	}
+	if ratings["a"].AveragePlayerSkill <= ratings["c"].AveragePlayerSkill {
+		t.Fatalf("Expected the winners to be rated higher, got %v", ratings)
+	}
+
+	// the second game starts from the stored ratings.
+	second, err := storage.RateAndStore[string](store, [][]string{{"a"}, {"c"}}, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second["a"].AveragePlayerSkill <= ratings["a"].AveragePlayerSkill {
+		t.Fatalf("Expected a to improve, got %v", second["a"])
+	}
+
+	record, _ := store.Get("a")
+	if record.Version != 2 {
+		t.Fatalf("Expected version 2, got %d", record.Version)
+	}
+}
+
+func TestRateAndStoreAt(t *testing.T) {
+	store, err := storage.OpenFileStore[string](t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	tau := 0.1
+	options := openskill.Options{TauPerDay: &tau}
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first, err := storage.RateAndStoreAt[string](store, [][]string{{"a"}, {"b"}}, options, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, _ := store.Get("a")
+	if !record.LastPlayed.Equal(start) {
+		t.Fatalf("Expected the match time to be stored, got %v", record.LastPlayed)
+	}
+
+	// a plain write keeps the time of the last match.
+	if record, err = store.Put("a", record.Rating, record.Version); err != nil {
+		t.Fatal(err)
+	}
+	if !record.LastPlayed.Equal(start) {
+		t.Fatalf("Expected the match time to be kept, got %v", record.LastPlayed)
+	}
+
+	later := start.Add(50 * 24 * time.Hour)
+	second, err := storage.RateAndStoreAt[string](store, [][]string{{"a"}, {"b"}}, options, later)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// rate the same match without decay, to see the decay made a difference.
+	undecayed, err := openskill.RateRosters([]openskill.Roster[string]{
+		openskill.NewRoster(openskill.Player[string]{ID: "a", Rating: first["a"]}),
+		openskill.NewRoster(openskill.Player[string]{ID: "b", Rating: first["b"]}),
+	}, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second["a"].SkillUncertaintyDegree <= undecayed["a"].SkillUncertaintyDegree {
+		t.Fatalf("Expected the inactivity to grow the uncertainty, got %v", second["a"])
+	}
+
+	record, _ = store.Get("b")
+	if !record.LastPlayed.Equal(later) {
+		t.Fatalf("Expected the match time to be stored, got %v", record.LastPlayed)
+	}
+}
@@ -8,3 +8,17 @@ func NewTeam(teams ...*Rating) Team {
 
 	return Team(slc)
 }
+
+// copyTeam returns a deep copy of a team, so the ratings can be changed without
+// touching the ones owned by the caller.
+func copyTeam(team Team) Team {
+	ratings := make([]Rating, len(team))
+	result := make(Team, len(team))
+
+	for index, item := range team {
+		ratings[index] = *item
+		result[index] = &ratings[index]
+	}
+
+	return result
+}
@@ -0,0 +1,78 @@
+// Writes a parity fixture of openskill.js, ../openskill_js_cases.json, from the inputs in
+// cases.json. openskill.js has no margin, so the cases that set one are left out.
+//
+// Run it from this directory with openskill.js 4 installed:
+//
+//     npm install openskill@4
+//     node generate.mjs
+
+import { readFileSync, writeFileSync } from 'node:fs'
+import { predictDraw, predictWin, rate, rating } from 'openskill'
+import {
+  bradleyTerryFull,
+  bradleyTerryPart,
+  plackettLuce,
+  thurstoneMostellerFull,
+  thurstoneMostellerPart,
+} from 'openskill/models'
+
+const models = {
+  PlackettLuce: plackettLuce,
+  BradleyTerryFull: bradleyTerryFull,
+  BradleyTerryPart: bradleyTerryPart,
+  ThurstoneMostellerFull: thurstoneMostellerFull,
+  ThurstoneMostellerPart: thurstoneMostellerPart,
+}
+
+const cases = JSON.parse(readFileSync('cases.json', 'utf8'))
+
+const ratings = (teams) => teams.map((team) => team.map(({ mu, sigma }) => rating({ mu, sigma })))
+
+const compact = (value) => JSON.stringify(value).replaceAll(',"', ', "').replaceAll('":', '": ').replaceAll(',{', ', {').replaceAll(',[', ', [')
+
+const rateVectors = cases.models.flatMap((name) =>
+  cases.rate
+    .filter((vector) => vector.margin === undefined)
+    .map(({ name: caseName, ...vector }) => {
+      const result = rate(ratings(vector.teams), {
+        model: models[name],
+        rank: vector.rankings,
+        score: vector.scores,
+        weight: vector.weights,
+        tau: vector.tau,
+        preventSigmaIncrease: vector.limitSigma,
+      })
+
+      return {
+        name: `${name} ${caseName}`,
+        model: name,
+        ...vector,
+        expected: result.map((team) => team.map(({ mu, sigma }) => ({ mu, sigma }))),
+      }
+    })
+)
+
+const predictions = (vectors, predict) =>
+  vectors.map((vector) => ({ ...vector, tolerance: cases.predictionTolerance, expected: predict(ratings(vector.teams)) }))
+
+const section = (vectors) => vectors.map((vector) => `    ${compact(vector)}`).join(',\n')
+
+writeFileSync(
+  '../openskill_js_cases.json',
+  [
+    '{',
+    '  "source": "Written by testdata/parity/generate/generate.mjs with openskill.js.",',
+    `  "tolerance": ${cases.tolerance},`,
+    '  "rate": [',
+    section(rateVectors),
+    '  ],',
+    '  "predictWin": [',
+    section(predictions(cases.predictWin, predictWin)),
+    '  ],',
+    '  "predictDraw": [',
+    section(predictions(cases.predictDraw, predictDraw)),
+    '  ]',
+    '}',
+    '',
+  ].join('\n')
+)
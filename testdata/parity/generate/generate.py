@@ -0,0 +1,88 @@
+"""Writes the parity fixture of openskill.py, ../openskill_py.json, from the inputs in cases.json.
+
+Run it from this directory with openskill.py 6 installed:
+
+    pip install "openskill>=6,<7"
+    python generate.py
+"""
+
+import json
+
+from openskill.models import (
+    BradleyTerryFull,
+    BradleyTerryPart,
+    PlackettLuce,
+    ThurstoneMostellerFull,
+    ThurstoneMostellerPart,
+)
+
+MODELS = {
+    "PlackettLuce": PlackettLuce,
+    "BradleyTerryFull": BradleyTerryFull,
+    "BradleyTerryPart": BradleyTerryPart,
+    "ThurstoneMostellerFull": ThurstoneMostellerFull,
+    "ThurstoneMostellerPart": ThurstoneMostellerPart,
+}
+
+
+def ratings(model, teams):
+    return [[model.rating(mu=player["mu"], sigma=player["sigma"]) for player in team] for team in teams]
+
+
+def compact(value):
+    return json.dumps(value, separators=(", ", ": "))
+
+
+def main():
+    with open("cases.json") as file:
+        cases = json.load(file)
+
+    rate = []
+    for name in cases["models"]:
+        for case in cases["rate"]:
+            model = MODELS[name](margin=case.get("margin", 0.0))
+            result = model.rate(
+                ratings(model, case["teams"]),
+                ranks=case.get("rankings"),
+                scores=case.get("scores"),
+                weights=case.get("weights"),
+                tau=case.get("tau"),
+                limit_sigma=case.get("limitSigma"),
+            )
+            vector = {key: value for key, value in case.items() if key != "name"}
+            vector = {"name": f"{name} {case['name']}", "model": name, **vector}
+            vector["expected"] = [[{"mu": player.mu, "sigma": player.sigma} for player in team] for team in result]
+            rate.append(vector)
+
+    model = PlackettLuce()
+    predict_win = [
+        {**case, "tolerance": cases["predictionTolerance"], "expected": model.predict_win(ratings(model, case["teams"]))}
+        for case in cases["predictWin"]
+    ]
+    predict_draw = [
+        {**case, "tolerance": cases["predictionTolerance"], "expected": model.predict_draw(ratings(model, case["teams"]))}
+        for case in cases["predictDraw"]
+    ]
+
+    lines = [
+        "{",
+        '  "source": "Written by testdata/parity/generate/generate.py with openskill.py.",',
+        f'  "tolerance": {cases["tolerance"]},',
+        '  "rate": [',
+        ",\n".join(f"    {compact(vector)}" for vector in rate),
+        "  ],",
+        '  "predictWin": [',
+        ",\n".join(f"    {compact(vector)}" for vector in predict_win),
+        "  ],",
+        '  "predictDraw": [',
+        ",\n".join(f"    {compact(vector)}" for vector in predict_draw),
+        "  ]",
+        "}",
+    ]
+
+    with open("../openskill_py.json", "w") as file:
+        file.write("\n".join(lines) + "\n")
+
+
+if __name__ == "__main__":
+    main()
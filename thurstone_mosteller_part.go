@@ -17,51 +17,48 @@ func ThurstoneMostellerPart(game []Team, options *Options) []Team {
 	epsilon := epsilon(options)
 	tbs := betaSq(options) * 2
 	_gamma := gamma(options)
+	_margin := marginFactor(options)
 
 	teamRatings := teamRatings(options)(game)
-	adjacentTeams := ladderPairs(teamRatings)
+	trace := newTracer(options, "ThurstoneMostellerPart", teamRatings)
 
-	zipper := lo.Zip2(teamRatings, adjacentTeams)
+	result := make([]Team, len(teamRatings))
 
-	return lo.Map(zipper, func(item lo.Tuple2[*teamRating, []*teamRating], index int) Team {
-		iTeamRating, iAdjacents := item.Unpack()
+	for index, item := range teamRatings {
+		var iMu, iSigmaSq, iRank = item.TeamMu, item.TeamSigmaSq, item.Rank
+		var omegaSum, deltaSum float64
 
-		var iMu, iSigmaSq, iTeam, iRank = iTeamRating.TeamMu, iTeamRating.TeamSigmaSq, iTeamRating.Team, iTeamRating.Rank
-
-		_sums := lo.Reduce(iAdjacents, func(agg sums, localItem *teamRating, index int) sums {
+		adjacentTeams(teamRatings, index, func(localItem *teamRating) {
 			var qMu, qSigmaSq, qRank = localItem.TeamMu, localItem.TeamSigmaSq, localItem.Rank
 
 			ciq := 2 * math.Sqrt(iSigmaSq+qSigmaSq+tbs)
 			deltaMu := (iMu - qMu) / ciq
 			sigSqToCiq := iSigmaSq / ciq
-			iGamma := _gamma(ciq, int64(len(teamRatings)), iTeamRating.TeamMu, iTeamRating.TeamSigmaSq, iTeamRating.Team, iTeamRating.Rank)
+			iGamma := _gamma(ciq, int64(len(teamRatings)), item.TeamMu, item.TeamSigmaSq, item.Team, item.Rank)
 
-			if qRank == iRank {
-				agg.omegaSum += sigSqToCiq * vt(deltaMu, epsilon/ciq)
-				agg.deltaSum += ((iGamma * sigSqToCiq) / ciq) * wt(deltaMu, epsilon/ciq)
+			margin, omega, delta := 1.0, 0.0, 0.0
 
-				return agg
+			if qRank == iRank {
+				omega = sigSqToCiq * vt(deltaMu, epsilon/ciq)
+				delta = ((iGamma * sigSqToCiq) / ciq) * wt(deltaMu, epsilon/ciq)
+			} else {
+				sign := lo.Ternary(qRank > iRank, 1.0, -1.0)
+
+				margin = _margin(item, localItem)
+				omega = margin * sign * sigSqToCiq * v(sign*deltaMu, epsilon/ciq)
+				delta = ((iGamma * sigSqToCiq) / ciq) * w(sign*deltaMu, epsilon/ciq)
 			}
 
-			sign := lo.Ternary(qRank > iRank, 1.0, -1.0)
-
-			agg.omegaSum += sign * sigSqToCiq * v(sign*deltaMu, epsilon/ciq)
-			agg.deltaSum += ((iGamma * sigSqToCiq) / ciq) * w(sign*deltaMu, epsilon/ciq)
+			omegaSum += omega
+			deltaSum += delta
 
-			return agg
-		}, sums{omegaSum: 0, deltaSum: 0})
+			trace.opponent(index, localItem, OpponentTrace{C: ciq, Gamma: iGamma, Margin: margin, Omega: omega, Delta: delta})
+		})
 
-		result := lo.Map([]*Rating(*iTeam), func(finalItem *Rating, index int) *Rating {
-			sigmaSq := math.Pow(finalItem.SkillUncertaintyDegree, 2)
-			mu := finalItem.AveragePlayerSkill + (sigmaSq/iSigmaSq)*_sums.omegaSum
-			sigma := finalItem.SkillUncertaintyDegree * math.Sqrt(math.Max(1-(sigmaSq/iSigmaSq)*_sums.deltaSum, epsilon))
+		trace.update(index, omegaSum, deltaSum)
 
-			return &Rating{
-				AveragePlayerSkill:     mu,
-				SkillUncertaintyDegree: sigma,
-			}
-		})
+		result[index] = updateTeam(item, omegaSum, deltaSum, epsilon)
+	}
 
-		return Team(result)
-	})
+	return result
 }
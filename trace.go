@@ -0,0 +1,139 @@
+package openskill
+
+import "sort"
+
+// Trace records the intermediate quantities computed by a model while rating a match, so a rating
+// change can be explained after the fact. It is filled when set on Options.Trace, and it can be
+// serialized to JSON. It is only filled by the Weng-Lin models: PlackettLuce, BradleyTerryFull,
+// BradleyTerryPart, ThurstoneMostellerFull and ThurstoneMostellerPart.
+type Trace struct {
+	// Model is the name of the model that rated the match.
+	Model string `json:"model"`
+
+	// Teams holds the trace of every team. When the match is rated through Rate, the teams follow
+	// the order they were given in, otherwise the order they were given to the model.
+	Teams []TeamTrace `json:"teams"`
+}
+
+// TeamTrace holds the quantities computed for a team. The update of each player of the team is
+// derived from Omega and Delta, as described in the Weng-Lin paper.
+type TeamTrace struct {
+	// Index is the position of the team.
+	Index int `json:"index"`
+
+	// Rank is the dense rank of the team, where 0 is the best.
+	Rank int64 `json:"rank"`
+
+	// Mu and SigmaSq are the skill and the variance of the team, after Options.Tau and the weights
+	// of the players were applied.
+	Mu      float64 `json:"mu"`
+	SigmaSq float64 `json:"sigmaSq"`
+
+	// C, SumQ, A and Gamma are only set by PlackettLuce, which computes them once per team: C is
+	// the normalizing constant shared by every team, SumQ the sum of exp(mu / C) over the teams
+	// ranked at the same place or below, A the amount of teams sharing the rank, and Gamma the
+	// factor that dampens the change of the uncertainty.
+	C     float64 `json:"c,omitempty"`
+	SumQ  float64 `json:"sumQ,omitempty"`
+	A     int64   `json:"a,omitempty"`
+	Gamma float64 `json:"gamma,omitempty"`
+
+	// Omega is the change of the skill of the team, and Delta the factor by which its variance shrinks.
+	Omega float64 `json:"omega"`
+	Delta float64 `json:"delta"`
+
+	// Opponents holds the contribution of every team compared against this one to Omega and Delta.
+	Opponents []OpponentTrace `json:"opponents"`
+}
+
+// OpponentTrace holds the contribution of the comparison against a team to the update of another.
+// In PlackettLuce, the team is compared against itself as well.
+type OpponentTrace struct {
+	// Index is the position of the opponent, following the same order as TeamTrace.Index.
+	Index int `json:"index"`
+
+	// C is the normalizing constant of the comparison, ciq on the pairwise models.
+	C float64 `json:"c"`
+
+	// Gamma is the factor that dampens the change of the uncertainty, computed for each comparison
+	// by the pairwise models.
+	Gamma float64 `json:"gamma,omitempty"`
+
+	// Margin is the margin of victory factor applied to the comparison, 1 when none is applied.
+	Margin float64 `json:"margin"`
+
+	// Omega and Delta are the contributions to the Omega and Delta of the team.
+	Omega float64 `json:"omega"`
+	Delta float64 `json:"delta"`
+}
+
+// tracer fills a Trace from within a model. A nil tracer records nothing, so models can use it
+// unconditionally.
+type tracer struct {
+	trace   *Trace
+	indices map[*teamRating]int
+}
+
+// newTracer resets the trace of the options for a model, returning nil when tracing is off.
+func newTracer(options *Options, model string, teamRatings []*teamRating) *tracer {
+	if options == nil || options.Trace == nil {
+		return nil
+	}
+
+	t := &tracer{trace: options.Trace, indices: make(map[*teamRating]int)}
+
+	*t.trace = Trace{Model: model, Teams: make([]TeamTrace, len(teamRatings))}
+
+	for i, item := range teamRatings {
+		t.indices[item] = i
+		t.trace.Teams[i] = TeamTrace{Index: i, Rank: item.Rank, Mu: item.TeamMu, SigmaSq: item.TeamSigmaSq, Opponents: make([]OpponentTrace, 0)}
+	}
+
+	return t
+}
+
+// team returns the trace of a team, or nil when tracing is off.
+func (t *tracer) team(index int) *TeamTrace {
+	if t == nil {
+		return nil
+	}
+
+	return &t.trace.Teams[index]
+}
+
+// opponent records the comparison of a team against another.
+func (t *tracer) opponent(index int, q *teamRating, opponent OpponentTrace) {
+	if t == nil {
+		return
+	}
+
+	opponent.Index = t.indices[q]
+	t.trace.Teams[index].Opponents = append(t.trace.Teams[index].Opponents, opponent)
+}
+
+// update records the final update of a team.
+func (t *tracer) update(index int, omega, delta float64) {
+	if t == nil {
+		return
+	}
+
+	t.trace.Teams[index].Omega = omega
+	t.trace.Teams[index].Delta = delta
+}
+
+// reorder maps the indices of a trace filled by a model back to the order the teams were given to
+// Rate in, given the order they were sorted in, and sorts the teams by it.
+func (trace *Trace) reorder(tenet []int) {
+	for i := range trace.Teams {
+		team := &trace.Teams[i]
+		team.Index = tenet[team.Index]
+
+		for j := range team.Opponents {
+			team.Opponents[j].Index = tenet[team.Opponents[j].Index]
+		}
+	}
+
+	sort.SliceStable(trace.Teams, func(i, j int) bool {
+		return trace.Teams[i].Index < trace.Teams[j].Index
+	})
+}
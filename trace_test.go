@@ -0,0 +1,85 @@
+package openskill_test
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+func TestTrace(t *testing.T) {
+	for name, model := range parityModels {
+		if name == "TrueSkill" {
+			continue
+		}
+
+		model := model
+		teams := []openskill.Team{
+			openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 20, SkillUncertaintyDegree: 8}),
+			openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 30, SkillUncertaintyDegree: 5}),
+			openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 25, SkillUncertaintyDegree: 3}),
+			openskill.NewTeam(&openskill.Rating{AveragePlayerSkill: 27, SkillUncertaintyDegree: 6}),
+		}
+
+		trace := &openskill.Trace{}
+		margin := 2.0
+		// the teams get sorted before being rated, and the trace must follow the original order.
+		options := openskill.Options{Model: &model, Scores: []int64{10, 1, 4, 4}, Margin: &margin, Trace: trace}
+
+		rated := openskill.Rate(teams, options)
+
+		untraced := options
+		untraced.Trace = nil
+		if expected := openskill.Rate(teams, untraced); !reflect.DeepEqual(snapshot(rated), snapshot(expected)) {
+			t.Errorf("%s: expected tracing not to change the ratings", name)
+		}
+
+		if trace.Model != name || len(trace.Teams) != len(teams) {
+			t.Fatalf("%s: unexpected trace %+v", name, trace)
+		}
+
+		for i, team := range trace.Teams {
+			before, after := teams[i][0], rated[i][0]
+
+			if team.Index != i || team.Mu != before.AveragePlayerSkill || team.SigmaSq != before.SkillUncertaintyDegree*before.SkillUncertaintyDegree {
+				t.Errorf("%s: unexpected trace of team %d: %+v", name, i, team)
+			}
+
+			var omega, delta float64
+			for _, opponent := range team.Opponents {
+				omega += opponent.Omega
+				delta += opponent.Delta
+			}
+			if math.Abs(omega-team.Omega) > 1e-12 || math.Abs(delta-team.Delta) > 1e-12 {
+				t.Errorf("%s: expected the opponents of team %d to add up to its update", name, i)
+			}
+
+			// with a single player, the team update is the player update.
+			if math.Abs(after.AveragePlayerSkill-before.AveragePlayerSkill-team.Omega) > 1e-12 {
+				t.Errorf("%s: expected team %d to move by %v, got %v", name, i, team.Omega, after.AveragePlayerSkill-before.AveragePlayerSkill)
+			}
+			if expected := before.SkillUncertaintyDegree * math.Sqrt(1-team.Delta); math.Abs(after.SkillUncertaintyDegree-expected) > 1e-12 {
+				t.Errorf("%s: expected team %d to have an uncertainty of %v, got %v", name, i, expected, after.SkillUncertaintyDegree)
+			}
+		}
+
+		// team 1 only scored 1, so it lost to team 0 by more than the margin.
+		for _, opponent := range trace.Teams[1].Opponents {
+			if opponent.Index == 0 && opponent.Margin <= 1 {
+				t.Errorf("%s: expected a margin factor against team 0, got %v", name, opponent.Margin)
+			}
+		}
+
+		data, err := json.Marshal(trace)
+		if err != nil {
+			t.Fatalf("%s: expected the trace to be serializable, got %v", name, err)
+		}
+
+		var decoded openskill.Trace
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded.Teams[2].Omega != trace.Teams[2].Omega {
+			t.Errorf("%s: expected the trace to survive a round trip, got %v", name, err)
+		}
+	}
+}
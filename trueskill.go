@@ -0,0 +1,339 @@
+package openskill
+
+import (
+	"math"
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// trueSkillMinDelta is the smallest change on the messages of the team difference
+// layer for the message passing schedule to keep iterating.
+const trueSkillMinDelta = 0.0001
+
+// trueSkillMaxIterations bounds how many times the team difference layer is iterated.
+const trueSkillMaxIterations = 10
+
+// gaussian is a normal distribution in its natural parameters, precision and precision adjusted mean,
+// which makes the products and divisions used by the message passing cheap.
+type gaussian struct {
+	pi  float64
+	tau float64
+}
+
+func newGaussian(mu, sigma float64) gaussian {
+	pi := 1 / (sigma * sigma)
+	return gaussian{pi: pi, tau: pi * mu}
+}
+
+func (g gaussian) mu() float64 {
+	if g.pi == 0 {
+		return 0
+	}
+	return g.tau / g.pi
+}
+
+func (g gaussian) sigma() float64 {
+	if g.pi == 0 {
+		return math.Inf(1)
+	}
+	return math.Sqrt(1 / g.pi)
+}
+
+func (g gaussian) mul(other gaussian) gaussian {
+	return gaussian{pi: g.pi + other.pi, tau: g.tau + other.tau}
+}
+
+func (g gaussian) div(other gaussian) gaussian {
+	return gaussian{pi: g.pi - other.pi, tau: g.tau - other.tau}
+}
+
+// variable is a node of the factor graph, holding its current marginal and the last
+// message received from each of the factors it is connected to.
+type variable struct {
+	value    gaussian
+	messages map[factor]gaussian
+}
+
+type factor interface{}
+
+func newVariable() *variable {
+	return &variable{messages: make(map[factor]gaussian)}
+}
+
+func (v *variable) set(value gaussian) float64 {
+	delta := v.delta(value)
+	v.value = value
+	return delta
+}
+
+func (v *variable) delta(other gaussian) float64 {
+	piDelta := math.Abs(v.value.pi - other.pi)
+	if math.IsInf(piDelta, 0) {
+		return 0
+	}
+	return math.Max(math.Abs(v.value.tau-other.tau), math.Sqrt(piDelta))
+}
+
+func (v *variable) updateMessage(f factor, message gaussian) float64 {
+	old := v.messages[f]
+	v.messages[f] = message
+	return v.set(v.value.div(old).mul(message))
+}
+
+func (v *variable) updateValue(f factor, value gaussian) float64 {
+	old := v.messages[f]
+	v.messages[f] = value.mul(old).div(v.value)
+	return v.set(value)
+}
+
+// priorFactor connects a skill variable to the rating of a player.
+type priorFactor struct {
+	variable *variable
+	rating   *Rating
+}
+
+func (f *priorFactor) down() float64 {
+	return f.variable.updateValue(f, newGaussian(f.rating.AveragePlayerSkill, f.rating.SkillUncertaintyDegree))
+}
+
+// likelihoodFactor connects a skill variable to a performance variable, adding the performance variance.
+type likelihoodFactor struct {
+	mean     *variable
+	value    *variable
+	variance float64
+}
+
+func (f *likelihoodFactor) a(g gaussian) float64 {
+	return 1 / (1 + f.variance*g.pi)
+}
+
+func (f *likelihoodFactor) down() float64 {
+	message := f.mean.value.div(f.mean.messages[f])
+	a := f.a(message)
+	return f.value.updateMessage(f, gaussian{pi: a * message.pi, tau: a * message.tau})
+}
+
+func (f *likelihoodFactor) up() float64 {
+	message := f.value.value.div(f.value.messages[f])
+	a := f.a(message)
+	return f.mean.updateMessage(f, gaussian{pi: a * message.pi, tau: a * message.tau})
+}
+
+// sumFactor connects a variable to a weighted sum of other variables.
+type sumFactor struct {
+	sum    *variable
+	terms  []*variable
+	coeffs []float64
+}
+
+func (f *sumFactor) down() float64 {
+	messages := lo.Map(f.terms, func(item *variable, index int) gaussian {
+		return item.messages[f]
+	})
+	return f.update(f.sum, f.terms, messages, f.coeffs)
+}
+
+func (f *sumFactor) up(index int) float64 {
+	coeff := f.coeffs[index]
+	coeffs := lo.Map(f.coeffs, func(item float64, localIndex int) float64 {
+		if coeff == 0 {
+			return 0
+		}
+		if localIndex == index {
+			return 1 / coeff
+		}
+		return -item / coeff
+	})
+
+	values := append([]*variable{}, f.terms...)
+	values[index] = f.sum
+
+	messages := lo.Map(values, func(item *variable, index int) gaussian {
+		return item.messages[f]
+	})
+
+	return f.update(f.terms[index], values, messages, coeffs)
+}
+
+func (f *sumFactor) update(target *variable, values []*variable, messages []gaussian, coeffs []float64) float64 {
+	var piInv, mu float64
+
+	for i, value := range values {
+		div := value.value.div(messages[i])
+		mu += coeffs[i] * div.mu()
+
+		if math.IsInf(piInv, 1) {
+			continue
+		}
+		if div.pi == 0 {
+			piInv = math.Inf(1)
+			continue
+		}
+		piInv += coeffs[i] * coeffs[i] / div.pi
+	}
+
+	pi := 1 / piInv
+
+	return target.updateMessage(f, gaussian{pi: pi, tau: pi * mu})
+}
+
+// truncateFactor applies the observed outcome, a win or a draw, to a team difference variable.
+type truncateFactor struct {
+	variable   *variable
+	v          func(x, t float64) float64
+	w          func(x, t float64) float64
+	drawMargin float64
+}
+
+func (f *truncateFactor) up() float64 {
+	div := f.variable.value.div(f.variable.messages[f])
+	sqrtPi := math.Sqrt(div.pi)
+
+	x, t := div.tau/sqrtPi, f.drawMargin*sqrtPi
+	v, w := f.v(x, t), f.w(x, t)
+	denom := 1 - w
+
+	return f.variable.updateValue(f, gaussian{pi: div.pi / denom, tau: (div.tau + sqrtPi*v) / denom})
+}
+
+func drawProbability(options *Options) float64 {
+	if options != nil && options.DrawProbability != nil {
+		return *options.DrawProbability
+	}
+	return 0.1
+}
+
+// TrueSkill is an implementation of the TrueSkill ranking model, which uses message passing
+// on a factor graph made of the skill and performance of every player, the performance of
+// every team, and the performance difference between teams that are adjacent on the ranking.
+// It supports any amount of teams, ties, and partial play through Options.Weights. The chance
+// of a draw is set through Options.DrawProbability. This function accepts the a slice with the
+// team that are competing, plus an options parameter, with things such as scores and previous
+// rankings. The function return a slice of teams that are properly ranked.
+func TrueSkill(game []Team, options *Options) []Team {
+	beta := beta(options)
+	betaSq := beta * beta
+	drawProbability := drawProbability(options)
+
+	teamRatings := teamRatings(options)(game)
+
+	// the team difference layer is built between teams that are adjacent on the ranking.
+	sortedRatings := append([]*teamRating{}, teamRatings...)
+	sort.SliceStable(sortedRatings, func(i, j int) bool {
+		return sortedRatings[i].Rank < sortedRatings[j].Rank
+	})
+
+	skills := make(map[*teamRating][]*variable)
+	performances := make(map[*teamRating][]*variable)
+	teamPerformances := make(map[*teamRating]*variable)
+
+	var priorLayer []*priorFactor
+	var performanceLayer []*likelihoodFactor
+	var teamPerformanceLayer []*sumFactor
+
+	for _, item := range sortedRatings {
+		teamPerformance := newVariable()
+		teamPerformances[item] = teamPerformance
+
+		for _, rating := range *item.Team {
+			skill := newVariable()
+			performance := newVariable()
+
+			skills[item] = append(skills[item], skill)
+			performances[item] = append(performances[item], performance)
+
+			priorLayer = append(priorLayer, &priorFactor{variable: skill, rating: rating})
+			performanceLayer = append(performanceLayer, &likelihoodFactor{mean: skill, value: performance, variance: betaSq})
+		}
+
+		teamPerformanceLayer = append(teamPerformanceLayer, &sumFactor{sum: teamPerformance, terms: performances[item], coeffs: item.Weights})
+	}
+
+	var teamDifferenceLayer []*sumFactor
+	var truncateLayer []*truncateFactor
+
+	for i := 0; i < len(sortedRatings)-1; i++ {
+		left, right := sortedRatings[i], sortedRatings[i+1]
+		difference := newVariable()
+
+		teamDifferenceLayer = append(teamDifferenceLayer, &sumFactor{
+			sum:    difference,
+			terms:  []*variable{teamPerformances[left], teamPerformances[right]},
+			coeffs: []float64{1, -1},
+		})
+
+		size := float64(len(*left.Team) + len(*right.Team))
+		drawMargin := ppf((drawProbability+1)/2) * math.Sqrt(size) * beta
+
+		if left.Rank == right.Rank {
+			truncateLayer = append(truncateLayer, &truncateFactor{variable: difference, v: vt, w: wt, drawMargin: drawMargin})
+		} else {
+			truncateLayer = append(truncateLayer, &truncateFactor{variable: difference, v: v, w: w, drawMargin: drawMargin})
+		}
+	}
+
+	for _, f := range priorLayer {
+		f.down()
+	}
+	for _, f := range performanceLayer {
+		f.down()
+	}
+	for _, f := range teamPerformanceLayer {
+		f.down()
+	}
+
+	if len(teamDifferenceLayer) > 0 {
+		size := len(teamDifferenceLayer)
+
+		for iteration := 0; iteration < trueSkillMaxIterations; iteration++ {
+			var delta float64
+
+			if size == 1 {
+				teamDifferenceLayer[0].down()
+				delta = truncateLayer[0].up()
+			} else {
+				for i := 0; i < size-1; i++ {
+					teamDifferenceLayer[i].down()
+					delta = math.Max(delta, truncateLayer[i].up())
+					teamDifferenceLayer[i].up(1)
+				}
+				for i := size - 1; i > 0; i-- {
+					teamDifferenceLayer[i].down()
+					delta = math.Max(delta, truncateLayer[i].up())
+					teamDifferenceLayer[i].up(0)
+				}
+			}
+
+			if delta <= trueSkillMinDelta {
+				break
+			}
+		}
+
+		teamDifferenceLayer[0].up(0)
+		teamDifferenceLayer[size-1].up(1)
+	}
+
+	// a player with a weight of 0 takes no part in the performance of their team, so they receive
+	// no message and keep their rating.
+	for _, f := range teamPerformanceLayer {
+		for i := range f.terms {
+			if f.coeffs[i] != 0 {
+				f.up(i)
+			}
+		}
+	}
+	for _, f := range performanceLayer {
+		f.up()
+	}
+
+	return lo.Map(teamRatings, func(item *teamRating, index int) Team {
+		return lo.Map(skills[item], func(skill *variable, localIndex int) *Rating {
+			rating := *(*item.Team)[localIndex]
+			rating.AveragePlayerSkill = skill.value.mu()
+			rating.SkillUncertaintyDegree = skill.value.sigma()
+
+			return &rating
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package openskill_test
+
+import (
+	"testing"
+
+	"github.com/eullerpereira94/openskill"
+)
+
+func TestTrueSkill(t *testing.T) {
+	// Reference values from the documentation of the trueskill Python package,
+	// which uses a dynamic factor of sigma / 100 and a draw probability of 10%.
+	tau := 25.0 / 300
+	model := openskill.Model(openskill.TrueSkill)
+
+	newTeams := func(amount int) []openskill.Team {
+		teams := []openskill.Team{}
+		for i := 0; i < amount; i++ {
+			teams = append(teams, openskill.NewTeam(openskill.NewRating(nil, nil)))
+		}
+		return teams
+	}
+
+	cases := []struct {
+		name     string
+		teams    []openskill.Team
+		rankings []int64
+		expected []openskill.Rating
+	}{
+		{"1v1", newTeams(2), nil, []openskill.Rating{{AveragePlayerSkill: 29.396, SkillUncertaintyDegree: 7.171}, {AveragePlayerSkill: 20.604, SkillUncertaintyDegree: 7.171}}},
+		{"1v1 draw", newTeams(2), []int64{1, 1}, []openskill.Rating{{AveragePlayerSkill: 25, SkillUncertaintyDegree: 6.458}, {AveragePlayerSkill: 25, SkillUncertaintyDegree: 6.458}}},
+		{"free for all", newTeams(3), nil, []openskill.Rating{{AveragePlayerSkill: 31.675, SkillUncertaintyDegree: 6.656}, {AveragePlayerSkill: 25, SkillUncertaintyDegree: 6.208}, {AveragePlayerSkill: 18.325, SkillUncertaintyDegree: 6.656}}},
+	}
+
+	for _, c := range cases {
+		result, err := openskill.RateE(c.teams, openskill.Options{Model: &model, Tau: &tau, Rankings: c.rankings})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", c.name, err)
+		}
+
+		for i, team := range result {
+			if !withinTolerance(c.expected[i].AveragePlayerSkill, team[0].AveragePlayerSkill, 1e-4) || !withinTolerance(c.expected[i].SkillUncertaintyDegree, team[0].SkillUncertaintyDegree, 1e-4) {
+				t.Errorf("%s: expected team %d to be rated %v, got %v", c.name, i, c.expected[i], *team[0])
+			}
+		}
+	}
+}
+
+func TestTrueSkillZeroWeight(t *testing.T) {
+	model := openskill.Model(openskill.TrueSkill)
+	teams := []openskill.Team{
+		openskill.NewTeam(openskill.NewRating(nil, nil), &openskill.Rating{AveragePlayerSkill: 30, SkillUncertaintyDegree: 5}),
+		openskill.NewTeam(openskill.NewRating(nil, nil)),
+	}
+
+	result, err := openskill.RateE(teams, openskill.Options{Model: &model, Weights: [][]float64{{1, 0}, {1}}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if *result[0][1] != *teams[0][1] {
+		t.Errorf("Expected a player with a weight of 0 to keep their rating, got %v", *result[0][1])
+	}
+
+	if !(result[0][0].AveragePlayerSkill > 25) || !(result[1][0].AveragePlayerSkill < 25) {
+		t.Errorf("Expected the other players to be rated as a regular win, got %v and %v", *result[0][0], *result[1][0])
+	}
+}
@@ -13,6 +13,11 @@ type Rating struct {
 	// This value is used to set the bounds of the
 	// probalistic distribution.
 	SkillUncertaintyDegree float64
+
+	// Volatility represents how erratic the performance
+	// of a player is. It is only used by the Glicko2
+	// model, and when zero, Options.Volatility is used.
+	Volatility float64
 }
 
 // Team is nothing more than a collection of Ratings
@@ -29,6 +34,8 @@ type Model func(teams []Team, options *Options) []Team
 // Options contains the values provided of the constants used by the rating system,
 // plus optional rankings and scores of the teams to be rated, plus a optional paramenter
 // that stops a player true skill rating from going after a victory, which can feel unfair.
+// The constants can be serialized to JSON, while the functions and the fields that describe
+// a single match cannot, which is how MatchLog stores them.
 type Options struct {
 	// StandardizedPlayerSkill is a constant that is set in way that, the following assertion
 	// is always true:
@@ -37,55 +44,115 @@ type Options struct {
 	//
 	// The bigger this constant is, the smaller the degree of uncertainty relative to average skill.
 	// When not set,it defaults to 3.
-	StandardizedPlayerSkill *float64
+	StandardizedPlayerSkill *float64 `json:"standardizedPlayerSkill,omitempty"`
 
 	// AveragePlayerSkill represents the default value of a player average skill level.
 	// When not set, it defaults to 25.
-	AveragePlayerSkill *float64
+	AveragePlayerSkill *float64 `json:"averagePlayerSkill,omitempty"`
 
 	// SkillUncertaintyDegree represents the default value of uncertainty for a player skill.
 	// When not set, it defaults to Options.AveragePlayerSkill / Options.NormalizedPlayerSkill
-	SkillUncertaintyDegree *float64
+	SkillUncertaintyDegree *float64 `json:"skillUncertaintyDegree,omitempty"`
 
 	// SmallPositive is a value to use when a ranking model tries to update a player uncertainty
 	// with a negative value. It is rarely needed to do such substitutions.
 	// When not set, it defaults to 0.001
-	SmallPositive *float64
+	SmallPositive *float64 `json:"smallPositive,omitempty"`
 
 	// GammaFunction is a pointer to a function that is used to adjust
 	// how much SkillUncertaintyDegree can vary. When not set, it defaults
 	// to an internal implementation that mirrors the one found on item 6.1 of the
 	// Weng-Lin paper for the Packett-Luce model.
-	GammaFunction *Gamma
+	GammaFunction *Gamma `json:"-"`
+
+	// Beta represents the standard deviation of a player performance around their skill.
+	// When not set, it defaults to Options.SkillUncertaintyDegree / 2.
+	Beta *float64 `json:"beta,omitempty"`
 
 	// VarianceForTeamPerformance represents a constant to adjust the value of a team
-	// performance. When not set, it defaults to (Options.SkillUncertaintyDegree / 2) ^ 2.
+	// performance. When not set, it defaults to Options.Beta ^ 2.
 	// The default value for this constant takes into consideration if Options.SkillUncertaintyDegree is set or if
 	// either Options.AveragePlayerSkill or Options.NormalizedPlayerSkill are set.
-	VarianceForTeamPerformance *float64
+	VarianceForTeamPerformance *float64 `json:"varianceForTeamPerformance,omitempty"`
 
 	// Model represents the current model of ranking used. When not set, it defaults to Plackett-Luce.
-	Model *Model
+	Model *Model `json:"-"`
 
 	// Rankings is a optional slice of rankings that is used when provided order of the teams for the
 	// Rate function differs from the actual order of rankings. Other use for this field is to indicate
 	// when ties happened after a competition.
-	Rankings []int64
-
-	// Scores is slice of the scores of the teams after competing. In this implementation
-	// is a slice of integer values because otherwise, a lot of the internal operations
-	// would use maps. This field doesn't need to be initialized with values.
-	Scores []int64
-
-	weight [][]float64 // There is currently no provided model that takes into account of partial play or contribution, keeping this field because the original project also do so
+	Rankings []int64 `json:"-"`
+
+	// Scores is slice of the scores of the teams after competing. Use Options.FloatScores
+	// for scores that are not whole numbers. This field doesn't need to be initialized with values.
+	Scores []int64 `json:"-"`
+
+	// FloatRankings is the fractional counterpart of Options.Rankings, for games where placements
+	// are not whole numbers. When it is set, it takes precedence over Options.Rankings.
+	FloatRankings []float64 `json:"-"`
+
+	// FloatScores is the fractional counterpart of Options.Scores, for games that report things such
+	// as times, percentages or fractional points. Higher scores are better, so race times must be
+	// negated, or provided through Options.FloatRankings instead. When it is set, it takes precedence
+	// over Options.Scores.
+	FloatScores []float64 `json:"-"`
+
+	// TieTolerance is the largest difference between two rankings or two scores for them to be
+	// considered a tie. When not set, it defaults to 0, meaning only equal values are tied.
+	TieTolerance *float64 `json:"tieTolerance,omitempty"`
+
+	// Margin is an optional threshold that enables margin of victory aware updates. When it is set,
+	// and Options.Scores or Options.FloatScores are provided, every pair of teams whose scores
+	// differ by more than Margin has its skill update scaled up by 1 + ln(difference / Margin), so a
+	// decisive win moves ratings more than a narrow one. Differences up to Margin behave like a
+	// regular win. In the Plackett-Luce model, the update of a team over all the teams it outranked
+	// is scaled by the average of the factors against each of them. This scaling is specific to this
+	// package and differs from the margin of openskill.py, so ratings updated with a margin are not
+	// portable across them. It must be a positive value. Only the Plackett-Luce, Bradley-Terry and
+	// Thurstone-Mosteller models honour it: RateE rejects it for any other model, and Rate ignores it.
+	Margin *float64 `json:"margin,omitempty"`
+
+	// Weights is an optional matrix, aligned with the teams being rated, with the contribution
+	// of each player to their team. A weight of 1 means the player took part in the whole match,
+	// while smaller values mean partial play, such as a player that joined or left mid-match.
+	// The weights scale how much a player counts towards the team skill and uncertainty, and
+	// how much of the team update is applied to that player. Missing entries default to 1.
+	Weights [][]float64 `json:"-"`
+
+	// DrawProbability is the chance of a match ending in a draw, used by the TrueSkill model
+	// to compute the draw margin. When not set, it defaults to 0.1.
+	DrawProbability *float64 `json:"drawProbability,omitempty"`
+
+	// EloKFactor is the maximum change of Elo rating in a single game, used by the Elo model.
+	// When not set, it defaults to 32.
+	EloKFactor *float64 `json:"eloKFactor,omitempty"`
+
+	// Volatility is the volatility of a new player, used by the Glicko2 model.
+	// When not set, it defaults to 0.06.
+	Volatility *float64 `json:"volatility,omitempty"`
+
+	// VolatilityConstraint constrains how much the volatility of a player can change
+	// between rating periods, and is only used by the Glicko2 model. Reasonable values
+	// are between 0.3 and 1.2. When not set, it defaults to 0.5.
+	VolatilityConstraint *float64 `json:"volatilityConstraint,omitempty"`
 
 	// Tau is a value that prevents the uncertainty to drop to a value that is too low.
 	// Setting this constant, allows the rating to stay pliable even after many games.
 	// A suggested value for this constant is Options.AveragePlayerSkill / 300.
-	Tau *float64
+	Tau *float64 `json:"tau,omitempty"`
+
+	// TauPerDay is how much the uncertainty of a player grows for each day without playing, so
+	// players returning after a long break recalibrate quickly. Like Options.Tau, it is added to
+	// the variance, and the uncertainty never grows past Options.SkillUncertaintyDegree. It is used
+	// by DecayRating. When not set, it defaults to 0, meaning the uncertainty doesn't grow with time.
+	TauPerDay *float64 `json:"tauPerDay,omitempty"`
+
+	// Trace is an optional pointer to a Trace that, when set, is filled with the intermediate
+	// quantities computed by the model while rating, to explain the changes of the ratings.
+	Trace *Trace `json:"-"`
 
 	// PreventUncertaintyIncrease is an optional boolean value that, if it is set, and if Options.Tau is set,
 	// prevents the uncertainty value to increase, thus stopping the fringe case when the Ordinal of player
 	// rating decrease after a victory, which can feel unfair.
-	PreventUncertaintyIncrease *bool
+	PreventUncertaintyIncrease *bool `json:"preventUncertaintyIncrease,omitempty"`
 }
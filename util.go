@@ -12,12 +12,9 @@ type teamRating struct {
 	TeamMu      float64
 	TeamSigmaSq float64
 	Team        *Team
+	Weights     []float64
 	Rank        int64
-}
-
-type sums struct {
-	omegaSum float64
-	deltaSum float64
+	Score       float64
 }
 
 func rankings(teams []Team, ranks []int64) []int64 {
@@ -42,105 +39,241 @@ func rankings(teams []Team, ranks []int64) []int64 {
 	return outrank
 }
 
+// teamRatings returns a function that aggregates the players of each team of a game. The ranks of
+// the result never decrease from one team to the next, and every team shares a single backing
+// array, both for the aggregates and for the weights of the players.
 func teamRatings(options *Options) func(game []Team) []*teamRating {
 	return func(game []Team) []*teamRating {
 		var rank []int64
-		if options != nil && options.Rankings != nil {
+		if options != nil && len(options.FloatRankings) > 0 {
+			rank = rankings(game, denseRanks(options.FloatRankings, tieTolerance(options)))
+		} else if options != nil && options.Rankings != nil {
 			rank = rankings(game, options.Rankings)
 		} else {
 			rank = rankings(game, []int64{})
 		}
 
-		return lo.Map(game, func(item Team, index int) *teamRating {
-			mu := lo.Sum(lo.Map([]*Rating(item), func(item *Rating, index int) float64 {
-				return item.AveragePlayerSkill
-			}))
-			sigma := lo.Sum(lo.Map([]*Rating(item), func(item *Rating, index int) float64 {
-				return math.Pow(item.SkillUncertaintyDegree, 2)
-			}))
-
-			return &teamRating{
-				Team:        &item,
-				TeamMu:      mu,
-				TeamSigmaSq: sigma,
-				Rank:        rank[index],
+		scores := teamScores(options)
+
+		players := 0
+		for _, team := range game {
+			players += len(team)
+		}
+
+		items := make([]teamRating, len(game))
+		weights := make([]float64, players)
+		result := make([]*teamRating, len(game))
+
+		for index := range game {
+			team := game[index]
+			teamWeights := weights[:len(team):len(team)]
+			weights = weights[len(team):]
+
+			fillTeamWeights(options, index, teamWeights)
+
+			var mu, sigmaSq float64
+			for player, rating := range team {
+				mu += teamWeights[player] * rating.AveragePlayerSkill
+				sigma := teamWeights[player] * rating.SkillUncertaintyDegree
+				sigmaSq += sigma * sigma
 			}
-		})
+
+			item := &items[index]
+			item.Team = &game[index]
+			item.TeamMu = mu
+			item.TeamSigmaSq = sigmaSq
+			item.Weights = teamWeights
+			item.Rank = rank[index]
+			if index < len(scores) {
+				item.Score = scores[index]
+			}
+
+			result[index] = item
+		}
+
+		return result
 	}
 }
 
-func ladderPairs[T any](slc []*T) [][]*T {
-	size := len(slc)
+// teamWeights returns the contribution weights of the players of a team, defaulting to 1
+// for every player without an entry in Options.Weights.
+func teamWeights(options *Options, teamIndex int, teamSize int) []float64 {
+	weights := make([]float64, teamSize)
 
-	var left, right []*T = make([]*T, 0), make([]*T, 0)
+	fillTeamWeights(options, teamIndex, weights)
 
-	// bail earlier
-	if size == 1 {
-		return [][]*T{}
-	}
+	return weights
+}
 
-	left = append(left, nil)
-	left = append(left, slc[0:size-1]...)
+// subgameOptions returns the options for a game made of some of the teams of the game they were
+// set for, given by their positions, so each team keeps its own weights. The outcome of the full
+// game does not apply to the new one, so it is left out. When teams is nil, the teams of the new
+// game are formed anew, and none of the weights are kept either.
+func subgameOptions(options *Options, teams []int) *Options {
+	if options == nil {
+		return nil
+	}
 
-	right = append(right, slc[1:]...)
-	right = append(right, nil)
+	result := *options
+	result.Rankings, result.Scores, result.FloatRankings, result.FloatScores = nil, nil, nil, nil
+	result.Weights = nil
 
-	zip := lo.Zip2(left, right)
+	if len(options.Weights) > 0 && teams != nil {
+		result.Weights = lo.Map(teams, func(team int, index int) []float64 {
+			if team < len(options.Weights) {
+				return options.Weights[team]
+			}
+			return nil
+		})
+	}
 
-	return lo.Map(zip, func(item lo.Tuple2[*T, *T], index int) []*T {
-		l, r := item.Unpack()
+	return &result
+}
 
-		if l != nil && r != nil {
-			return []*T{l, r}
+// fillTeamWeights fills weights with the contribution weights of the players of a team.
+func fillTeamWeights(options *Options, teamIndex int, weights []float64) {
+	for i := range weights {
+		weights[i] = 1
+		if options != nil && teamIndex < len(options.Weights) && i < len(options.Weights[teamIndex]) {
+			weights[i] = options.Weights[teamIndex][i]
 		}
-		if l != nil && r == nil {
-			return []*T{l}
+	}
+}
+
+// teamScores returns the scores of the teams as floating-point values, preferring
+// Options.FloatScores over Options.Scores. It returns nil when no scores were provided.
+func teamScores(options *Options) []float64 {
+	if options == nil {
+		return nil
+	}
+
+	if len(options.FloatScores) > 0 {
+		return options.FloatScores
+	}
+
+	if len(options.Scores) > 0 {
+		return lo.Map(options.Scores, func(item int64, index int) float64 {
+			return float64(item)
+		})
+	}
+
+	return nil
+}
+
+// marginFactor returns a function that tells how much the skill update between two teams
+// must be scaled by, given the difference of their scores. Without a margin or without
+// scores, every pair of teams is scaled by 1.
+func marginFactor(options *Options) func(i, q *teamRating) float64 {
+	if options == nil || options.Margin == nil || len(teamScores(options)) == 0 {
+		return func(i, q *teamRating) float64 {
+			return 1
 		}
-		if l == nil && r != nil {
-			return []*T{r}
+	}
+
+	margin := *options.Margin
+
+	return func(i, q *teamRating) float64 {
+		difference := math.Abs(i.Score - q.Score)
+		if difference <= margin {
+			return 1
 		}
 
-		return []*T{} // this should really only happen when size == 1
-	})
+		return 1 + math.Log(difference/margin)
+	}
+}
+
+// updateTeam applies the omega and delta values computed by a model for a team
+// to each of its players, proportionally to the share each player has on the team uncertainty.
+func updateTeam(item *teamRating, omega, delta, epsilon float64) Team {
+	players := *item.Team
+	ratings := make([]Rating, len(players))
+	team := make(Team, len(players))
+
+	for index, player := range players {
+		weight := item.Weights[index]
+		sigmaSq := player.SkillUncertaintyDegree * player.SkillUncertaintyDegree
+
+		ratings[index] = *player
+		ratings[index].AveragePlayerSkill = player.AveragePlayerSkill + weight*(sigmaSq/item.TeamSigmaSq)*omega
+		ratings[index].SkillUncertaintyDegree = player.SkillUncertaintyDegree * math.Sqrt(math.Max(1-weight*weight*(sigmaSq/item.TeamSigmaSq)*delta, epsilon))
+		team[index] = &ratings[index]
+	}
+
+	return team
+}
+
+// adjacentTeams calls visit with the teams next to a team on the ranking, the one above it first.
+// It is how the partial pairing models compare each team only against its neighbours.
+func adjacentTeams(teamRatings []*teamRating, index int, visit func(q *teamRating)) {
+	if index > 0 {
+		visit(teamRatings[index-1])
+	}
+	if index+1 < len(teamRatings) {
+		visit(teamRatings[index+1])
+	}
 }
 
 func utilC(options *Options) func(teamRatings []*teamRating) float64 {
 	betasq := betaSq(options)
 
 	return func(teamRatings []*teamRating) float64 {
-		return math.Sqrt(
-			lo.Sum(lo.Map(teamRatings, func(item *teamRating, index int) float64 {
-				return item.TeamSigmaSq + betasq
-			})),
-		)
+		var sum float64
+		for _, item := range teamRatings {
+			sum += item.TeamSigmaSq + betasq
+		}
+
+		return math.Sqrt(sum)
 	}
 }
 
+// utilSumQ returns, for each team, the sum of exp(mu / c) over the teams ranked at the same place
+// or below it. As the ranks never decrease, it is a suffix sum, computed once for every rank.
 func utilSumQ(teamRatings []*teamRating, c float64) []float64 {
-	return lo.Map(teamRatings, func(item *teamRating, index int) float64 {
-		filteredRatings := lo.Filter(teamRatings, func(localItem *teamRating, index int) bool {
-			return localItem.Rank >= item.Rank
-		})
-		mappedFilteredRatings := lo.Map(filteredRatings, func(localItem *teamRating, index int) float64 {
-			return math.Exp(localItem.TeamMu / c)
-		})
+	sumQ := make([]float64, len(teamRatings))
 
-		return lo.Sum(mappedFilteredRatings)
-	})
+	var sum float64
+	for end := len(teamRatings); end > 0; {
+		start := end - 1
+		for start > 0 && teamRatings[start-1].Rank == teamRatings[end-1].Rank {
+			start--
+		}
+
+		for _, item := range teamRatings[start:end] {
+			sum += math.Exp(item.TeamMu / c)
+		}
+		for i := start; i < end; i++ {
+			sumQ[i] = sum
+		}
+
+		end = start
+	}
+
+	return sumQ
 }
 
+// utilA returns, for each team, how many teams share its rank. As the ranks never decrease, the
+// teams sharing a rank are next to each other.
 func utilA(teamRatings []*teamRating) []int64 {
-	return lo.Map(teamRatings, func(item *teamRating, index int) int64 {
-		filteredRatings := lo.Filter(teamRatings, func(localItem *teamRating, index int) bool {
-			return item.Rank == localItem.Rank
-		})
+	a := make([]int64, len(teamRatings))
 
-		return int64(len(filteredRatings))
-	})
+	for start := 0; start < len(teamRatings); {
+		end := start + 1
+		for end < len(teamRatings) && teamRatings[end].Rank == teamRatings[start].Rank {
+			end++
+		}
+
+		for i := start; i < end; i++ {
+			a[i] = int64(end - start)
+		}
+
+		start = end
+	}
+
+	return a
 }
 
 func gamma(options *Options) Gamma {
-	if options.GammaFunction != nil {
+	if options != nil && options.GammaFunction != nil {
 		return *options.GammaFunction
 	}
 
@@ -159,31 +292,34 @@ func score(q, i int64) float64 {
 	return 0.5
 }
 
-func unwind[T constraints.Integer, R any](order []T, collection []R) (sortedCollection []R, stochasticTenet []T) {
+func unwind[T constraints.Ordered, R any](order []T, collection []R) (sortedCollection []R, stochasticTenet []int) {
 	if len(collection) <= 0 {
 		sortedCollection = make([]R, 0)
-		stochasticTenet = make([]T, 0)
+		stochasticTenet = make([]int, 0)
 		return
 	}
 
-	zipped := []struct {
+	zipped := make([]struct {
 		x T
-		y T
+		y int
 		z R
-	}{}
+	}, 0, len(collection))
 
 	for i, v := range collection {
 		zipped = append(zipped, struct {
 			x T
-			y T
+			y int
 			z R
-		}{x: order[i], y: T(i), z: v})
+		}{x: order[i], y: i, z: v})
 	}
 
-	sort.Slice(zipped, func(i, j int) bool {
+	sort.SliceStable(zipped, func(i, j int) bool {
 		return zipped[i].x < zipped[j].x
 	})
 
+	sortedCollection = make([]R, 0, len(zipped))
+	stochasticTenet = make([]int, 0, len(zipped))
+
 	for _, v := range zipped {
 		sortedCollection = append(sortedCollection, v.z)
 		stochasticTenet = append(stochasticTenet, v.y)
@@ -191,3 +327,41 @@ func unwind[T constraints.Integer, R any](order []T, collection []R) (sortedColl
 
 	return
 }
+
+// permute returns the items of collection in the order given by tenet, as returned by unwind.
+func permute[R any](collection []R, tenet []int) []R {
+	return lo.Map(tenet, func(item int, index int) R {
+		return collection[item]
+	})
+}
+
+// padded returns a copy of collection with at least size items, filling the missing ones with zero values.
+func padded[R any](collection []R, size int) []R {
+	result := make([]R, lo.Max([]int{size, len(collection)}))
+	copy(result, collection)
+
+	return result
+}
+
+// denseRanks converts a slice of placements to integer ranks. Placements that are within
+// tolerance of the best placement of a group are considered tied, and share the same rank.
+func denseRanks(values []float64, tolerance float64) []int64 {
+	_, order := unwind(values, values)
+
+	ranks := make([]int64, len(values))
+
+	var rank int64 = 0
+	var groupStart float64
+
+	for i, index := range order {
+		if i == 0 {
+			groupStart = values[index]
+		} else if values[index]-groupStart > tolerance {
+			rank++
+			groupStart = values[index]
+		}
+		ranks[index] = rank
+	}
+
+	return ranks
+}
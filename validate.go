@@ -0,0 +1,179 @@
+package openskill
+
+import (
+	"fmt"
+	"math"
+)
+
+func isFinite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
+
+// validateOptions checks that every constant set on options is inside its valid range.
+func validateOptions(options *Options) error {
+	if options == nil {
+		return nil
+	}
+
+	if options.StandardizedPlayerSkill != nil && !isFinite(*options.StandardizedPlayerSkill) {
+		return fmt.Errorf("%w: StandardizedPlayerSkill must be finite", ErrInvalidOption)
+	}
+	if options.AveragePlayerSkill != nil && !isFinite(*options.AveragePlayerSkill) {
+		return fmt.Errorf("%w: AveragePlayerSkill must be finite", ErrInvalidOption)
+	}
+	if options.SkillUncertaintyDegree != nil && !(isFinite(*options.SkillUncertaintyDegree) && *options.SkillUncertaintyDegree > 0) {
+		return fmt.Errorf("%w: SkillUncertaintyDegree must be positive and finite", ErrInvalidOption)
+	}
+	if options.Beta != nil && !(isFinite(*options.Beta) && *options.Beta > 0) {
+		return fmt.Errorf("%w: Beta must be positive and finite", ErrInvalidOption)
+	}
+	if options.SmallPositive != nil && !(isFinite(*options.SmallPositive) && *options.SmallPositive > 0) {
+		return fmt.Errorf("%w: SmallPositive must be positive and finite", ErrInvalidOption)
+	}
+	if options.VarianceForTeamPerformance != nil && !(isFinite(*options.VarianceForTeamPerformance) && *options.VarianceForTeamPerformance > 0) {
+		return fmt.Errorf("%w: VarianceForTeamPerformance must be positive and finite", ErrInvalidOption)
+	}
+	if options.Tau != nil && !(isFinite(*options.Tau) && *options.Tau >= 0) {
+		return fmt.Errorf("%w: Tau must be non-negative and finite", ErrInvalidOption)
+	}
+	if options.TauPerDay != nil && !(isFinite(*options.TauPerDay) && *options.TauPerDay >= 0) {
+		return fmt.Errorf("%w: TauPerDay must be non-negative and finite", ErrInvalidOption)
+	}
+	if options.Margin != nil && !(isFinite(*options.Margin) && *options.Margin > 0) {
+		return fmt.Errorf("%w: Margin must be positive and finite", ErrInvalidOption)
+	}
+	if options.DrawProbability != nil && !(*options.DrawProbability >= 0 && *options.DrawProbability < 1) {
+		return fmt.Errorf("%w: DrawProbability must be in the [0, 1) interval", ErrInvalidOption)
+	}
+	if options.EloKFactor != nil && !(isFinite(*options.EloKFactor) && *options.EloKFactor > 0) {
+		return fmt.Errorf("%w: EloKFactor must be positive and finite", ErrInvalidOption)
+	}
+	if options.Volatility != nil && !(isFinite(*options.Volatility) && *options.Volatility > 0) {
+		return fmt.Errorf("%w: Volatility must be positive and finite", ErrInvalidOption)
+	}
+	if options.VolatilityConstraint != nil && !(isFinite(*options.VolatilityConstraint) && *options.VolatilityConstraint > 0) {
+		return fmt.Errorf("%w: VolatilityConstraint must be positive and finite", ErrInvalidOption)
+	}
+	if options.TieTolerance != nil && !(isFinite(*options.TieTolerance) && *options.TieTolerance >= 0) {
+		return fmt.Errorf("%w: TieTolerance must be non-negative and finite", ErrInvalidOption)
+	}
+	if options.GammaFunction != nil && *options.GammaFunction == nil {
+		return fmt.Errorf("%w: GammaFunction is nil", ErrInvalidOption)
+	}
+	if options.Model != nil && *options.Model == nil {
+		return ErrNilModel
+	}
+
+	return nil
+}
+
+// marginModels are the models that honour Options.Margin.
+var marginModels = []Model{PlackettLuce, BradleyTerryFull, BradleyTerryPart, ThurstoneMostellerFull, ThurstoneMostellerPart}
+
+// validateMargin checks that Options.Margin is only set for a model that honours it.
+func validateMargin(options *Options, model Model) error {
+	if options == nil || options.Margin == nil || isModel(model, marginModels) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: Margin is not supported by the model", ErrInvalidOption)
+}
+
+// validateTeams checks that every team has at least one player and that every rating is usable by the models.
+func validateTeams(teams []Team) error {
+	if len(teams) == 0 {
+		return ErrNoTeams
+	}
+
+	for i, team := range teams {
+		if len(team) == 0 {
+			return fmt.Errorf("%w: team %d", ErrEmptyTeam, i)
+		}
+
+		for j, rating := range team {
+			if rating == nil {
+				return fmt.Errorf("%w: team %d, player %d", ErrNilRating, i, j)
+			}
+			if !isFinite(rating.AveragePlayerSkill) {
+				return fmt.Errorf("%w: team %d, player %d", ErrInvalidMu, i, j)
+			}
+			if !(isFinite(rating.SkillUncertaintyDegree) && rating.SkillUncertaintyDegree > 0) {
+				return fmt.Errorf("%w: team %d, player %d", ErrInvalidSigma, i, j)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateWeights checks that the weights, when provided, have one entry per player and
+// that every team has at least one player contributing to it.
+func validateWeights(teams []Team, weights [][]float64) error {
+	if len(weights) == 0 {
+		return nil
+	}
+
+	if len(weights) != len(teams) {
+		return fmt.Errorf("%w: got %d weight rows for %d teams", ErrWeightsLength, len(weights), len(teams))
+	}
+
+	for i, team := range teams {
+		if len(weights[i]) != len(team) {
+			return fmt.Errorf("%w: got %d weights for %d players on team %d", ErrWeightsLength, len(weights[i]), len(team), i)
+		}
+
+		var total float64
+		for j, weight := range weights[i] {
+			if !isFinite(weight) || weight < 0 {
+				return fmt.Errorf("%w: team %d, player %d", ErrInvalidWeight, i, j)
+			}
+			total += weight
+		}
+
+		if total <= 0 {
+			return fmt.Errorf("%w: team %d", ErrInvalidWeight, i)
+		}
+	}
+
+	return nil
+}
+
+// validate checks the teams and options given to Rate, so that a malformed input
+// can be rejected before any model touches it.
+func validate(teams []Team, options *Options) error {
+	if err := validateTeams(teams); err != nil {
+		return err
+	}
+
+	if err := validateOptions(options); err != nil {
+		return err
+	}
+
+	if options != nil {
+		if len(options.Rankings) > 0 && len(options.Rankings) != len(teams) {
+			return fmt.Errorf("%w: got %d rankings for %d teams", ErrRankingsLength, len(options.Rankings), len(teams))
+		}
+		if len(options.Scores) > 0 && len(options.Scores) != len(teams) {
+			return fmt.Errorf("%w: got %d scores for %d teams", ErrScoresLength, len(options.Scores), len(teams))
+		}
+		if len(options.FloatRankings) > 0 && len(options.FloatRankings) != len(teams) {
+			return fmt.Errorf("%w: got %d rankings for %d teams", ErrRankingsLength, len(options.FloatRankings), len(teams))
+		}
+		if len(options.FloatScores) > 0 && len(options.FloatScores) != len(teams) {
+			return fmt.Errorf("%w: got %d scores for %d teams", ErrScoresLength, len(options.FloatScores), len(teams))
+		}
+		for i := range teams {
+			if i < len(options.FloatRankings) && !isFinite(options.FloatRankings[i]) {
+				return fmt.Errorf("%w: ranking of team %d", ErrInvalidOutcome, i)
+			}
+			if i < len(options.FloatScores) && !isFinite(options.FloatScores[i]) {
+				return fmt.Errorf("%w: score of team %d", ErrInvalidOutcome, i)
+			}
+		}
+		if err := validateWeights(teams, options.Weights); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}